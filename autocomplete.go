@@ -0,0 +1,94 @@
+package switchboard
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord rejects autocomplete responses containing more than 25 choices.
+const maxAutocompleteChoices = 25
+
+// AutocompleteProvider computes the choices to suggest for the focused option of an autocomplete interaction.
+// options contains every option the user has filled in so far, including the focused one, keyed by option name.
+type AutocompleteProvider func(
+	session *discordgo.Session,
+	interaction *discordgo.InteractionCreate,
+	focused *discordgo.ApplicationCommandInteractionDataOption,
+	options map[string]*discordgo.ApplicationCommandInteractionDataOption,
+) ([]*discordgo.ApplicationCommandOptionChoice, error)
+
+func (s *Switchboard) AddAutocompleteProvider(name string, provider AutocompleteProvider) error {
+	if s.autocompleteProviders == nil {
+		s.autocompleteProviders = map[string]AutocompleteProvider{}
+	}
+
+	if _, exists := s.autocompleteProviders[name]; exists {
+		return fmt.Errorf("autocomplete provider %s: %w", name, ErrDuplicateAutocompleteProvider)
+	}
+
+	s.autocompleteProviders[name] = provider
+
+	return nil
+}
+
+func (s *Switchboard) getAutocompleteChoices(
+	invocation *Invocation,
+) ([]*discordgo.ApplicationCommandOptionChoice, error) {
+	interaction := invocation.Interaction
+
+	handler, err := invocation.Command.getHandler(interaction.ApplicationCommandData().Options)
+	if err != nil {
+		return nil, err
+	}
+
+	optionsMap := map[string]*discordgo.ApplicationCommandInteractionDataOption{}
+	var focused *discordgo.ApplicationCommandInteractionDataOption
+
+	for _, option := range getLeafOptions(interaction.ApplicationCommandData().Options) {
+		optionsMap[option.Name] = option
+		if option.Focused {
+			focused = option
+		}
+	}
+
+	if focused == nil {
+		return nil, ErrNoFocusedOption
+	}
+
+	field, found := getOptionField(handler, focused.Name, invocation.Command.getNamingStrategy())
+	if !found {
+		return nil, fmt.Errorf("option %s: %w", focused.Name, ErrUnknownOption)
+	}
+
+	providerName := field.Tag.Get("autocomplete")
+	provider, found := s.autocompleteProviders[providerName]
+	if !found {
+		return nil, fmt.Errorf("autocomplete provider %s: %w", providerName, ErrUnknownAutocompleteProvider)
+	}
+
+	choices, err := provider(invocation.Session, interaction, focused, optionsMap)
+	if err != nil {
+		return nil, fmt.Errorf("error getting autocomplete choices: %w", err)
+	}
+
+	if len(choices) > maxAutocompleteChoices {
+		choices = choices[:maxAutocompleteChoices]
+	}
+
+	return choices, nil
+}
+
+func (s *Switchboard) handleInteractionAutocomplete(invocation *Invocation) error {
+	choices, err := s.getAutocompleteChoices(invocation)
+	if err != nil {
+		return err
+	}
+
+	return invocation.Session.InteractionRespond(invocation.Interaction.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+}
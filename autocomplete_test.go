@@ -0,0 +1,140 @@
+package switchboard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func newAutocompleteTestSwitchboard(t *testing.T) *Switchboard {
+	t.Helper()
+
+	s := &Switchboard{}
+	err := s.AddCommand(&Command{
+		Name:        "inventory",
+		Description: "Look up an item in your inventory",
+		Handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct {
+			Item     string `description:"Item to look up" autocomplete:"items"`
+			Quantity int    `description:"Quantity" default:"1"`
+		}) {
+		},
+	})
+	if err != nil {
+		t.Fatalf("got unexpected error adding command: %s", err)
+	}
+
+	return s
+}
+
+func newAutocompleteInvocation(
+	s *Switchboard,
+	options ...*discordgo.ApplicationCommandInteractionDataOption,
+) *Invocation {
+	return &Invocation{
+		Interaction: &discordgo.InteractionCreate{
+			Interaction: &discordgo.Interaction{
+				Type: discordgo.InteractionApplicationCommandAutocomplete,
+				Data: discordgo.ApplicationCommandInteractionData{
+					Name:    "inventory",
+					Options: options,
+				},
+			},
+		},
+		Command: s.commands[0],
+	}
+}
+
+func TestSwitchboard_getAutocompleteChoices(t *testing.T) {
+	s := newAutocompleteTestSwitchboard(t)
+
+	err := s.AddAutocompleteProvider("items", func(
+		_ *discordgo.Session,
+		_ *discordgo.InteractionCreate,
+		focused *discordgo.ApplicationCommandInteractionDataOption,
+		options map[string]*discordgo.ApplicationCommandInteractionDataOption,
+	) ([]*discordgo.ApplicationCommandOptionChoice, error) {
+		if focused.StringValue() != "sw" {
+			t.Errorf("got unexpected focused value: %s", focused.StringValue())
+		}
+		if options["quantity"].IntValue() != 3 {
+			t.Errorf("got unexpected quantity: %d", options["quantity"].IntValue())
+		}
+
+		return []*discordgo.ApplicationCommandOptionChoice{
+			{Name: "Sword", Value: "sword"},
+		}, nil
+	})
+	if err != nil {
+		t.Errorf("got unexpected error adding autocomplete provider: %s", err)
+	}
+
+	choices, err := s.getAutocompleteChoices(newAutocompleteInvocation(s,
+		&discordgo.ApplicationCommandInteractionDataOption{
+			Name:    "item",
+			Type:    discordgo.ApplicationCommandOptionString,
+			Value:   "sw",
+			Focused: true,
+		},
+		&discordgo.ApplicationCommandInteractionDataOption{
+			Name:  "quantity",
+			Type:  discordgo.ApplicationCommandOptionInteger,
+			Value: 3.0,
+		},
+	))
+	if err != nil {
+		t.Errorf("got unexpected error getting choices: %s", err)
+	}
+
+	if diff := deep.Equal(
+		choices,
+		[]*discordgo.ApplicationCommandOptionChoice{
+			{Name: "Sword", Value: "sword"},
+		},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestSwitchboard_getAutocompleteChoices_WithUnknownProvider(t *testing.T) {
+	s := newAutocompleteTestSwitchboard(t)
+
+	_, err := s.getAutocompleteChoices(newAutocompleteInvocation(s,
+		&discordgo.ApplicationCommandInteractionDataOption{
+			Name:    "item",
+			Type:    discordgo.ApplicationCommandOptionString,
+			Value:   "sw",
+			Focused: true,
+		},
+	))
+
+	if err == nil {
+		t.Error("did not get expected error")
+	}
+	if !errors.Is(err, ErrUnknownAutocompleteProvider) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
+
+func TestSwitchboard_AddAutocompleteProvider_WithDuplicateName(t *testing.T) {
+	s := &Switchboard{}
+	provider := func(
+		_ *discordgo.Session,
+		_ *discordgo.InteractionCreate,
+		_ *discordgo.ApplicationCommandInteractionDataOption,
+		_ map[string]*discordgo.ApplicationCommandInteractionDataOption,
+	) ([]*discordgo.ApplicationCommandOptionChoice, error) {
+		return nil, nil
+	}
+
+	_ = s.AddAutocompleteProvider("items", provider)
+	err := s.AddAutocompleteProvider("items", provider)
+
+	if err == nil {
+		t.Error("did not get expected error")
+	}
+	if !errors.Is(err, ErrDuplicateAutocompleteProvider) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
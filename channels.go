@@ -0,0 +1,81 @@
+package switchboard
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// channelTypeForum is not yet defined by discordgo.
+const channelTypeForum discordgo.ChannelType = 15
+
+var channelTypeNames = map[string][]discordgo.ChannelType{
+	"text":                {discordgo.ChannelTypeGuildText},
+	"dm":                  {discordgo.ChannelTypeDM},
+	"voice":               {discordgo.ChannelTypeGuildVoice},
+	"group_dm":            {discordgo.ChannelTypeGroupDM},
+	"category":            {discordgo.ChannelTypeGuildCategory},
+	"announcement":        {discordgo.ChannelTypeGuildNews},
+	"announcement_thread": {discordgo.ChannelTypeGuildNewsThread},
+	"public_thread":       {discordgo.ChannelTypeGuildPublicThread},
+	"private_thread":      {discordgo.ChannelTypeGuildPrivateThread},
+	"stage":               {discordgo.ChannelTypeGuildStageVoice},
+	"forum":               {channelTypeForum},
+	"thread": {
+		discordgo.ChannelTypeGuildNewsThread,
+		discordgo.ChannelTypeGuildPublicThread,
+		discordgo.ChannelTypeGuildPrivateThread,
+	},
+}
+
+// getChannelTypes parses the channelTypes tag of an args struct field, which is a comma-separated list of channel
+// type names. Returns nil if the field has no channelTypes tag.
+func getChannelTypes(field reflect.StructField) ([]discordgo.ChannelType, error) {
+	tag, hasTag := field.Tag.Lookup("channelTypes")
+	if !hasTag {
+		return nil, nil
+	}
+
+	var channelTypes []discordgo.ChannelType
+	seen := map[discordgo.ChannelType]bool{}
+
+	for _, name := range strings.Split(tag, ",") {
+		types, validName := channelTypeNames[strings.TrimSpace(name)]
+		if !validName {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidChannelType, name)
+		}
+
+		for _, channelType := range types {
+			if !seen[channelType] {
+				seen[channelType] = true
+				channelTypes = append(channelTypes, channelType)
+			}
+		}
+	}
+
+	return channelTypes, nil
+}
+
+// checkChannelType ensures a channel provided for an option is one of the types allowed by the field's channelTypes
+// tag. Discord should already enforce this, but it is checked again to protect handlers from outdated command
+// registrations.
+func checkChannelType(field reflect.StructField, channelType discordgo.ChannelType) error {
+	channelTypes, err := getChannelTypes(field)
+	if err != nil {
+		return err
+	}
+
+	if channelTypes == nil {
+		return nil
+	}
+
+	for _, allowedType := range channelTypes {
+		if allowedType == channelType {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: channel type %d is not allowed for field %s", ErrDisallowedChannelType, channelType, field.Name)
+}
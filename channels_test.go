@@ -0,0 +1,163 @@
+package switchboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func Test_getCommandOptions_WithChannelTypes(t *testing.T) {
+	options, err := getCommandOptions(
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+			Channel discordgo.Channel `description:"Channel" channelTypes:"text,announcement,thread,public_thread"`
+		}) {
+		},
+		LowerCaseNaming,
+	)
+
+	if err != nil {
+		t.Errorf("got unexpected error getting command options: %s", err)
+	}
+
+	if diff := deep.Equal(
+		options,
+		[]*discordgo.ApplicationCommandOption{
+			{
+				Name:        "channel",
+				Required:    true,
+				Type:        discordgo.ApplicationCommandOptionChannel,
+				Description: "Channel",
+				ChannelTypes: []discordgo.ChannelType{
+					discordgo.ChannelTypeGuildText,
+					discordgo.ChannelTypeGuildNews,
+					discordgo.ChannelTypeGuildNewsThread,
+					discordgo.ChannelTypeGuildPublicThread,
+					discordgo.ChannelTypeGuildPrivateThread,
+				},
+			},
+		},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func Test_getCommandOptions_WithInvalidChannelTypes(t *testing.T) {
+	_, err := getCommandOptions(
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+			Channel discordgo.Channel `description:"Channel" channelTypes:"text,carrier_pigeon"`
+		}) {
+		},
+		LowerCaseNaming,
+	)
+
+	if err == nil {
+		t.Error("did not get expected error when getting command options")
+	}
+	if !errors.Is(err, ErrInvalidChannelType) {
+		t.Errorf("got unexpected error when getting command options: %s", err)
+	}
+
+	_, err = getCommandOptions(
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+			Name string `description:"Name" channelTypes:"text"`
+		}) {
+		},
+		LowerCaseNaming,
+	)
+
+	if err == nil {
+		t.Error("did not get expected error when getting command options")
+	}
+	if !errors.Is(err, ErrChannelTypesUnsupportedType) {
+		t.Errorf("got unexpected error when getting command options: %s", err)
+	}
+}
+
+func Test_invokeCommand_SlashCommand_WithDisallowedChannelType(t *testing.T) {
+	interactionData := discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name:  "channel",
+						Type:  discordgo.ApplicationCommandOptionChannel,
+						Value: "12345",
+					},
+				},
+				Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+					Channels: map[string]*discordgo.Channel{
+						"12345": {ID: "12345", Type: discordgo.ChannelTypeGuildVoice},
+					},
+				},
+			},
+		},
+	}
+
+	type Args struct {
+		Channel discordgo.Channel `channelTypes:"text"`
+	}
+
+	err := invokeCommand(
+		context.Background(),
+		&Command{
+			Type: SlashCommand,
+		},
+		nil,
+		&interactionData,
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args Args) {
+			t.Error("handler function unexpectedly called")
+		})
+
+	if err == nil {
+		t.Error("did not get expected error")
+	}
+	if !errors.Is(err, ErrDisallowedChannelType) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
+
+func Test_invokeCommand_SlashCommand_WithUnresolvedRestrictedChannel(t *testing.T) {
+	session, _ := newTestSession(t, func(_ *http.Request) (int, string) {
+		return http.StatusNotFound, `{"message": "Unknown Channel", "code": 10003}`
+	})
+
+	interactionData := discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name:  "channel",
+						Type:  discordgo.ApplicationCommandOptionChannel,
+						Value: "12345",
+					},
+				},
+				Resolved: &discordgo.ApplicationCommandInteractionDataResolved{},
+			},
+		},
+	}
+
+	type Args struct {
+		Channel discordgo.Channel `channelTypes:"text"`
+	}
+
+	err := invokeCommand(
+		context.Background(),
+		&Command{
+			Type: SlashCommand,
+		},
+		session,
+		&interactionData,
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args Args) {
+			t.Error("handler function unexpectedly called")
+		})
+
+	if !errors.Is(err, ErrUnresolvedValue) {
+		t.Errorf("got unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package switchboard
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord allows at most 25 choices for a single option.
+const maxOptionChoices = 25
+
+type Choice struct {
+	Name  string
+	Value any
+}
+
+// ChoiceProvider is implemented by named string, integer and float types to restrict options of that type to a fixed
+// set of values.
+type ChoiceProvider interface {
+	Choices() []Choice
+}
+
+var choiceProviderType = reflect.TypeOf((*ChoiceProvider)(nil)).Elem()
+
+var choiceKindTypes = map[reflect.Kind]reflect.Type{
+	reflect.String:  reflect.TypeOf(""),
+	reflect.Int:     reflect.TypeOf(0),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Float64: reflect.TypeOf(0.0),
+}
+
+// getBaseType returns the builtin type underlying a named choice type, or the given type if it is not one.
+func getBaseType(argType reflect.Type) reflect.Type {
+	if argType.Implements(choiceProviderType) {
+		if baseType, isChoiceKind := choiceKindTypes[argType.Kind()]; isChoiceKind {
+			return baseType
+		}
+	}
+
+	return argType
+}
+
+// parseChoicesTag parses a choices tag, which is a comma-separated list of either bare values or `name=value` pairs.
+func parseChoicesTag(tag string) []Choice {
+	//goland:noinspection GoPreferNilSlice
+	choices := []Choice{}
+
+	for _, entry := range strings.Split(tag, ",") {
+		name, value, hasName := strings.Cut(entry, "=")
+		if !hasName {
+			value = name
+		}
+
+		choices = append(choices, Choice{Name: strings.TrimSpace(name), Value: strings.TrimSpace(value)})
+	}
+
+	return choices
+}
+
+func isValidChoiceValue(value reflect.Value, baseType reflect.Type) bool {
+	if !value.IsValid() || !value.Type().ConvertibleTo(baseType) {
+		return false
+	}
+
+	// Integers are convertible to strings in Go, but should not be treated as valid string choices
+	if (value.Kind() == reflect.String) != (baseType.Kind() == reflect.String) {
+		return false
+	}
+
+	switch value.Kind() { //nolint:exhaustive
+	case reflect.String:
+		return true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Negative values would wrap around when converted to an unsigned type
+		return baseType.Kind() != reflect.Uint || value.Int() >= 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	case reflect.Float32, reflect.Float64:
+		if baseType.Kind() == reflect.Float64 {
+			return true
+		}
+
+		// Floats would be truncated when converted to an integer type, so only whole numbers are accepted
+		floatValue := value.Float()
+		return floatValue == math.Trunc(floatValue) && (baseType.Kind() != reflect.Uint || floatValue >= 0)
+	default:
+		return false
+	}
+}
+
+// getOptionChoices returns the choices for an args struct field, either from its type implementing ChoiceProvider or
+// from a choices tag. Returns nil if the field has no choices.
+func getOptionChoices(field reflect.StructField) ([]*discordgo.ApplicationCommandOptionChoice, error) {
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	baseType := getBaseType(fieldType)
+
+	var choices []Choice
+	choicesTag, hasChoicesTag := field.Tag.Lookup("choices")
+
+	if hasChoicesTag {
+		choices = parseChoicesTag(choicesTag)
+	} else if fieldType.Implements(choiceProviderType) {
+		choices = reflect.Zero(fieldType).Interface().(ChoiceProvider).Choices()
+	} else {
+		return nil, nil
+	}
+
+	if _, isChoiceKind := choiceKindTypes[baseType.Kind()]; !isChoiceKind {
+		return nil, ErrChoicesUnsupportedType
+	}
+
+	if len(choices) == 0 {
+		return nil, ErrNoChoices
+	}
+
+	if len(choices) > maxOptionChoices {
+		return nil, ErrTooManyChoices
+	}
+
+	optionChoices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(choices))
+
+	for _, choice := range choices {
+		var value reflect.Value
+
+		if rawValue, isString := choice.Value.(string); isString && hasChoicesTag {
+			parsedValue, err := parseStringValue(baseType, rawValue)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: %s", ErrInvalidChoiceValue, rawValue, err)
+			}
+			value = parsedValue
+		} else {
+			value = reflect.ValueOf(choice.Value)
+		}
+
+		if !isValidChoiceValue(value, baseType) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidChoiceValue, choice.Value)
+		}
+
+		optionChoices = append(optionChoices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  choice.Name,
+			Value: value.Convert(baseType).Interface(),
+		})
+	}
+
+	return optionChoices, nil
+}
@@ -0,0 +1,213 @@
+package switchboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+type testColour string
+
+func (testColour) Choices() []Choice {
+	return []Choice{
+		{Name: "Red", Value: "red"},
+		{Name: "Blue", Value: testColour("blue")},
+	}
+}
+
+type testPriority int
+
+func (testPriority) Choices() []Choice {
+	return []Choice{
+		{Name: "Low", Value: 1},
+		{Name: "High", Value: 2},
+	}
+}
+
+type testFractionalPriority int
+
+func (testFractionalPriority) Choices() []Choice {
+	return []Choice{
+		{Name: "Low", Value: 1.0},
+		{Name: "Medium", Value: 1.5},
+	}
+}
+
+type testFlagPriority int
+
+func (testFlagPriority) Choices() []Choice {
+	return []Choice{
+		{Name: "Low", Value: false},
+	}
+}
+
+func Test_getCommandOptions_WithChoices(t *testing.T) {
+	options, err := getCommandOptions(
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+			Colour   testColour    `description:"Colour"`
+			Priority *testPriority `description:"Priority"`
+			Size     string        `description:"Size" choices:"Small=s, Large=l"`
+			Ratio    float64       `description:"Ratio" choices:"0.5,1"`
+		}) {
+		},
+		LowerCaseNaming,
+	)
+
+	if err != nil {
+		t.Errorf("got unexpected error getting command options: %s", err)
+	}
+
+	if diff := deep.Equal(
+		options,
+		[]*discordgo.ApplicationCommandOption{
+			{
+				Name:        "colour",
+				Required:    true,
+				Type:        discordgo.ApplicationCommandOptionString,
+				Description: "Colour",
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Red", Value: "red"},
+					{Name: "Blue", Value: "blue"},
+				},
+			},
+			{
+				Name:        "priority",
+				Required:    false,
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Description: "Priority",
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Low", Value: 1},
+					{Name: "High", Value: 2},
+				},
+			},
+			{
+				Name:        "size",
+				Required:    true,
+				Type:        discordgo.ApplicationCommandOptionString,
+				Description: "Size",
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Small", Value: "s"},
+					{Name: "Large", Value: "l"},
+				},
+			},
+			{
+				Name:        "ratio",
+				Required:    true,
+				Type:        discordgo.ApplicationCommandOptionNumber,
+				Description: "Ratio",
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "0.5", Value: 0.5},
+					{Name: "1", Value: 1.0},
+				},
+			},
+		},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func Test_getCommandOptions_WithInvalidChoices(t *testing.T) {
+	tests := map[string]struct {
+		handler  any
+		expected error
+	}{
+		"unsupported type": {
+			handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+				Flag bool `description:"Flag" choices:"true,false"`
+			}) {
+			},
+			expected: ErrChoicesUnsupportedType,
+		},
+		"invalid value": {
+			handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+				Count int `description:"Count" choices:"one,two"`
+			}) {
+			},
+			expected: ErrInvalidChoiceValue,
+		},
+		"fractional integer value": {
+			handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+				Priority testFractionalPriority `description:"Priority"`
+			}) {
+			},
+			expected: ErrInvalidChoiceValue,
+		},
+		"non-numeric integer value": {
+			handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+				Priority testFlagPriority `description:"Priority"`
+			}) {
+			},
+			expected: ErrInvalidChoiceValue,
+		},
+		"with autocomplete": {
+			handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+				Size string `description:"Size" choices:"s,l" autocomplete:"sizes"`
+			}) {
+			},
+			expected: ErrChoicesWithAutocomplete,
+		},
+	}
+
+	for name, test := range tests {
+		_, err := getCommandOptions(test.handler, LowerCaseNaming)
+
+		if err == nil {
+			t.Errorf("%s: did not get expected error when getting command options", name)
+		}
+		if !errors.Is(err, test.expected) {
+			t.Errorf("%s: got unexpected error when getting command options: %s", name, err)
+		}
+	}
+}
+
+func Test_invokeCommand_SlashCommand_WithChoiceArgs(t *testing.T) {
+	interactionData := discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name:  "colour",
+						Type:  discordgo.ApplicationCommandOptionString,
+						Value: "blue",
+					},
+					{
+						Name:  "priority",
+						Type:  discordgo.ApplicationCommandOptionInteger,
+						Value: 2.0,
+					},
+				},
+			},
+		},
+	}
+
+	called := false
+	priority := testPriority(2)
+
+	type Args struct {
+		Colour   testColour
+		Priority *testPriority
+	}
+
+	invokeCommand(
+		context.Background(),
+		&Command{
+			Type: SlashCommand,
+		},
+		nil,
+		&interactionData,
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args Args) {
+			called = true
+
+			if diff := deep.Equal(args, Args{Colour: "blue", Priority: &priority}); diff != nil {
+				t.Error(diff)
+			}
+		})
+
+	if !called {
+		t.Error("handler function not called")
+	}
+}
@@ -11,11 +11,13 @@ type CommandType int
 const (
 	SlashCommand CommandType = iota
 	MessageCommand
+	UserCommand
 )
 
 var typeMap = map[CommandType]discordgo.ApplicationCommandType{
 	SlashCommand:   discordgo.ChatApplicationCommand,
 	MessageCommand: discordgo.MessageApplicationCommand,
+	UserCommand:    discordgo.UserApplicationCommand,
 }
 
 type Command struct {
@@ -24,17 +26,168 @@ type Command struct {
 	Handler     any
 	GuildID     string
 
+	// Middleware which wraps invocations of this command, inside of any middleware added to the Switchboard.
+	Middleware []Middleware
+
+	// Automatically defer the response if the handler does not respond in time. Handlers must respond using Respond
+	// for their response to be sent as an edit once deferred.
+	AutoDefer *AutoDefer
+
+	// Whether a global command can be used in DMs. Defaults to true, and has no effect on guild commands.
+	DMPermission *bool
+
+	// Slash commands may instead be split into subcommands and subcommand groups, in which case Handler must be nil.
+	SubCommands      []*SubCommand
+	SubCommandGroups []*SubCommandGroup
+
 	Type CommandType
+
+	// The NamingStrategy of the Switchboard the command was added to, captured when it was added.
+	naming NamingStrategy
+}
+
+type SubCommand struct {
+	Name        string
+	Description string
+	Handler     any
+}
+
+type SubCommandGroup struct {
+	Name        string
+	Description string
+	SubCommands []*SubCommand
+}
+
+// getNamingStrategy returns the NamingStrategy used for the command's options, falling back to DefaultNamingStrategy
+// for commands which have not been added to a Switchboard.
+func (c *Command) getNamingStrategy() NamingStrategy {
+	if c.naming != nil {
+		return c.naming
+	}
+
+	return DefaultNamingStrategy
+}
+
+func (c *Command) hasSubCommands() bool {
+	return len(c.SubCommands) > 0 || len(c.SubCommandGroups) > 0
+}
+
+func (c *Command) canRunInDMs() bool {
+	return c.GuildID == "" && (c.DMPermission == nil || *c.DMPermission)
+}
+
+// getSlashHandlers returns the handlers of every (sub)command that can be invoked through a slash command.
+func (c *Command) getSlashHandlers() []any {
+	if c.Type != SlashCommand {
+		return nil
+	}
+
+	if !c.hasSubCommands() {
+		return []any{c.Handler}
+	}
+
+	var handlers []any
+
+	for _, group := range c.SubCommandGroups {
+		for _, subCommand := range group.SubCommands {
+			handlers = append(handlers, subCommand.Handler)
+		}
+	}
+
+	for _, subCommand := range c.SubCommands {
+		handlers = append(handlers, subCommand.Handler)
+	}
+
+	return handlers
 }
 
-func (c *Command) validate() error {
-	err := validationFuncs[c.Type](c.Handler)
+func (c *Command) getSubCommandOptions() ([]*discordgo.ApplicationCommandOption, error) {
+	//goland:noinspection GoPreferNilSlice
+	options := []*discordgo.ApplicationCommandOption{}
 
+	for _, group := range c.SubCommandGroups {
+		groupOptions := []*discordgo.ApplicationCommandOption{}
+
+		for _, subCommand := range group.SubCommands {
+			option, err := subCommand.toDiscordOption(c.getNamingStrategy())
+			if err != nil {
+				return nil, fmt.Errorf("error getting options for subcommand %s %s: %w", group.Name, subCommand.Name, err)
+			}
+			groupOptions = append(groupOptions, option)
+		}
+
+		options = append(options, &discordgo.ApplicationCommandOption{
+			Name:        group.Name,
+			Description: group.Description,
+			Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+			Options:     groupOptions,
+		})
+	}
+
+	for _, subCommand := range c.SubCommands {
+		option, err := subCommand.toDiscordOption(c.getNamingStrategy())
+		if err != nil {
+			return nil, fmt.Errorf("error getting options for subcommand %s: %w", subCommand.Name, err)
+		}
+		options = append(options, option)
+	}
+
+	return options, nil
+}
+
+// getHandler walks the options of an incoming interaction to find the handler for the invoked (sub)command.
+func (c *Command) getHandler(options []*discordgo.ApplicationCommandInteractionDataOption) (any, error) {
+	if !c.hasSubCommands() {
+		return c.Handler, nil
+	}
+
+	if len(options) == 0 {
+		return nil, ErrUnknownSubCommand
+	}
+
+	subCommands := c.SubCommands
+	option := options[0]
+
+	if option.Type == discordgo.ApplicationCommandOptionSubCommandGroup {
+		subCommands = nil
+		for _, group := range c.SubCommandGroups {
+			if group.Name == option.Name {
+				subCommands = group.SubCommands
+				break
+			}
+		}
+
+		if len(option.Options) == 0 {
+			return nil, ErrUnknownSubCommand
+		}
+		option = option.Options[0]
+	}
+
+	if option.Type != discordgo.ApplicationCommandOptionSubCommand {
+		return nil, ErrUnknownSubCommand
+	}
+
+	for _, subCommand := range subCommands {
+		if subCommand.Name == option.Name {
+			return subCommand.Handler, nil
+		}
+	}
+
+	return nil, ErrUnknownSubCommand
+}
+
+func (s *SubCommand) toDiscordOption(naming NamingStrategy) (*discordgo.ApplicationCommandOption, error) {
+	options, err := getCommandOptions(s.Handler, naming)
 	if err != nil {
-		return fmt.Errorf("invalid handler: %w", err)
+		return nil, err
 	}
 
-	return nil
+	return &discordgo.ApplicationCommandOption{
+		Name:        s.Name,
+		Description: s.Description,
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Options:     options,
+	}, nil
 }
 
 func (c *Command) ToDiscordCommand() (*discordgo.ApplicationCommand, error) {
@@ -45,18 +198,24 @@ func (c *Command) ToDiscordCommand() (*discordgo.ApplicationCommand, error) {
 
 	var options []*discordgo.ApplicationCommandOption
 
-	if c.Type == SlashCommand {
-		options, err = getCommandOptions(c.Handler)
+	if c.hasSubCommands() {
+		options, err = c.getSubCommandOptions()
+		if err != nil {
+			return nil, fmt.Errorf("error getting subcommand options: %w", err)
+		}
+	} else if c.Type == SlashCommand {
+		options, err = getCommandOptions(c.Handler, c.getNamingStrategy())
 		if err != nil {
 			return nil, fmt.Errorf("error getting command options: %w", err)
 		}
 	}
 
 	return &discordgo.ApplicationCommand{
-		Name:        c.Name,
-		Description: c.Description,
-		GuildID:     c.GuildID,
-		Type:        typeMap[c.Type],
-		Options:     options,
+		Name:         c.Name,
+		Description:  c.Description,
+		GuildID:      c.GuildID,
+		Type:         typeMap[c.Type],
+		Options:      options,
+		DMPermission: c.DMPermission,
 	}, nil
 }
@@ -108,3 +108,258 @@ func TestCommand_ToDiscordCommand_WithValidCommand(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestCommand_ToDiscordCommand_WithSubCommands(t *testing.T) {
+	testCommand := &Command{
+		Name:        "config",
+		Description: "Manage configuration",
+		SubCommands: []*SubCommand{
+			{
+				Name:        "get",
+				Description: "Get a config value",
+				Handler: func(
+					_ *discordgo.Session,
+					_ *discordgo.InteractionCreate,
+					args struct {
+						Key string `description:"Key to get"`
+					},
+				) {
+				},
+			},
+		},
+		SubCommandGroups: []*SubCommandGroup{
+			{
+				Name:        "admin",
+				Description: "Admin operations",
+				SubCommands: []*SubCommand{
+					{
+						Name:        "reset",
+						Description: "Reset all config",
+						Handler:     func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {},
+					},
+				},
+			},
+		},
+	}
+
+	cmd, err := testCommand.ToDiscordCommand()
+
+	if err != nil {
+		t.Errorf("got unexpected error: %s", err)
+	}
+
+	if diff := deep.Equal(
+		cmd,
+		&discordgo.ApplicationCommand{
+			Name:        "config",
+			Description: "Manage configuration",
+			Type:        discordgo.ChatApplicationCommand,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Name:        "admin",
+					Description: "Admin operations",
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "reset",
+							Description: "Reset all config",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options:     []*discordgo.ApplicationCommandOption{},
+						},
+					},
+				},
+				{
+					Name:        "get",
+					Description: "Get a config value",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "key",
+							Description: "Key to get",
+							Type:        discordgo.ApplicationCommandOptionString,
+							Required:    true,
+						},
+					},
+				},
+			},
+		},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestCommand_ToDiscordCommand_WithSubCommandsAndHandler(t *testing.T) {
+	testCommand := &Command{
+		Name:        "config",
+		Description: "Manage configuration",
+		Handler:     func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {},
+		SubCommands: []*SubCommand{
+			{
+				Name:        "get",
+				Description: "Get a config value",
+				Handler:     func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {},
+			},
+		},
+	}
+
+	_, err := testCommand.ToDiscordCommand()
+
+	if err == nil {
+		t.Error("did not get expected error")
+	}
+	if !errors.Is(err, ErrSubCommandsWithHandler) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
+
+func TestCommand_ToDiscordCommand_WithInvalidSubCommandHandler(t *testing.T) {
+	testCommand := &Command{
+		Name:        "config",
+		Description: "Manage configuration",
+		SubCommandGroups: []*SubCommandGroup{
+			{
+				Name:        "admin",
+				Description: "Admin operations",
+				SubCommands: []*SubCommand{
+					{
+						Name:        "reset",
+						Description: "Reset all config",
+						Handler:     false,
+					},
+				},
+			},
+		},
+	}
+
+	_, err := testCommand.ToDiscordCommand()
+
+	if err == nil {
+		t.Error("did not get expected error")
+	}
+	if !errors.Is(err, ErrHandlerNotFunction) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
+
+func TestCommand_getHandler_WithSubCommands(t *testing.T) {
+	getCalled := false
+	resetCalled := false
+
+	testCommand := &Command{
+		Name: "config",
+		SubCommands: []*SubCommand{
+			{
+				Name: "get",
+				Handler: func() {
+					getCalled = true
+				},
+			},
+		},
+		SubCommandGroups: []*SubCommandGroup{
+			{
+				Name: "admin",
+				SubCommands: []*SubCommand{
+					{
+						Name: "reset",
+						Handler: func() {
+							resetCalled = true
+						},
+					},
+				},
+			},
+		},
+	}
+
+	handler, err := testCommand.getHandler([]*discordgo.ApplicationCommandInteractionDataOption{
+		{Name: "get", Type: discordgo.ApplicationCommandOptionSubCommand},
+	})
+	if err != nil {
+		t.Errorf("got unexpected error: %s", err)
+	}
+	handler.(func())()
+
+	handler, err = testCommand.getHandler([]*discordgo.ApplicationCommandInteractionDataOption{
+		{
+			Name: "admin",
+			Type: discordgo.ApplicationCommandOptionSubCommandGroup,
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{Name: "reset", Type: discordgo.ApplicationCommandOptionSubCommand},
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("got unexpected error: %s", err)
+	}
+	handler.(func())()
+
+	if !getCalled || !resetCalled {
+		t.Error("did not resolve expected handlers")
+	}
+
+	_, err = testCommand.getHandler([]*discordgo.ApplicationCommandInteractionDataOption{
+		{Name: "missing", Type: discordgo.ApplicationCommandOptionSubCommand},
+	})
+	if !errors.Is(err, ErrUnknownSubCommand) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
+
+func TestCommand_ToDiscordCommand_WithMemberOption(t *testing.T) {
+	handler := func(
+		_ *discordgo.Session,
+		_ *discordgo.InteractionCreate,
+		args struct {
+			Target discordgo.Member `description:"Target member"`
+		},
+	) {
+	}
+	dmPermission := false
+
+	_, err := (&Command{Name: "kick", Description: "Kick a member", Handler: handler}).ToDiscordCommand()
+	if err == nil {
+		t.Error("did not get expected error")
+	}
+	if !errors.Is(err, ErrMemberOptionInDMs) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+
+	_, err = (&Command{
+		Name:        "kick",
+		Description: "Kick a member",
+		Handler:     handler,
+		GuildID:     "1234567890",
+	}).ToDiscordCommand()
+	if err != nil {
+		t.Errorf("got unexpected error: %s", err)
+	}
+
+	cmd, err := (&Command{
+		Name:         "kick",
+		Description:  "Kick a member",
+		Handler:      handler,
+		DMPermission: &dmPermission,
+	}).ToDiscordCommand()
+	if err != nil {
+		t.Errorf("got unexpected error: %s", err)
+	}
+
+	if diff := deep.Equal(
+		cmd,
+		&discordgo.ApplicationCommand{
+			Name:         "kick",
+			Description:  "Kick a member",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: &dmPermission,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Name:        "target",
+					Description: "Target member",
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Required:    true,
+				},
+			},
+		},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
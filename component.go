@@ -0,0 +1,208 @@
+package switchboard
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+var patternParamNameRegexp = regexp.MustCompile(`^[-_\p{L}\p{N}]+$`)
+
+// customIDPattern matches custom IDs against a template such as `vote:{poll}:{choice}`. Each `{name}` segment
+// captures a parameter, and a trailing `*` matches any remaining suffix, allowing the pattern to be used as a prefix.
+type customIDPattern struct {
+	pattern string
+	regexp  *regexp.Regexp
+	params  []string
+}
+
+func compileCustomIDPattern(pattern string) (*customIDPattern, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("%w: pattern must not be empty", ErrInvalidCustomIDPattern)
+	}
+
+	compiled := &customIDPattern{pattern: pattern}
+	expr := strings.Builder{}
+	expr.WriteString("^")
+
+	remaining := strings.TrimSuffix(pattern, "*")
+
+	for remaining != "" {
+		start := strings.Index(remaining, "{")
+		if start == -1 {
+			expr.WriteString(regexp.QuoteMeta(remaining))
+			break
+		}
+
+		end := strings.Index(remaining[start:], "}")
+		if end == -1 {
+			return nil, fmt.Errorf("%w: unterminated parameter in %s", ErrInvalidCustomIDPattern, pattern)
+		}
+		end += start
+
+		name := remaining[start+1 : end]
+		if !patternParamNameRegexp.MatchString(name) {
+			return nil, fmt.Errorf("%w: invalid parameter name %q in %s", ErrInvalidCustomIDPattern, name, pattern)
+		}
+		for _, existing := range compiled.params {
+			if existing == name {
+				return nil, fmt.Errorf("%w: duplicate parameter %s in %s", ErrInvalidCustomIDPattern, name, pattern)
+			}
+		}
+
+		// A parameter directly before the trailing wildcard has no boundary, so would only ever capture one character
+		if end+1 == len(remaining) && strings.HasSuffix(pattern, "*") {
+			return nil, fmt.Errorf(
+				"%w: parameter %s must not be directly followed by * in %s",
+				ErrInvalidCustomIDPattern,
+				name,
+				pattern,
+			)
+		}
+
+		expr.WriteString(regexp.QuoteMeta(remaining[:start]))
+		expr.WriteString("(.+?)")
+		compiled.params = append(compiled.params, name)
+
+		remaining = remaining[end+1:]
+	}
+
+	if strings.HasSuffix(pattern, "*") {
+		expr.WriteString(".*")
+	}
+	expr.WriteString("$")
+
+	compiledRegexp, err := regexp.Compile(expr.String())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCustomIDPattern, err)
+	}
+	compiled.regexp = compiledRegexp
+
+	return compiled, nil
+}
+
+func (p *customIDPattern) match(customID string) (map[string]string, bool) {
+	matches := p.regexp.FindStringSubmatch(customID)
+	if matches == nil {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for index, name := range p.params {
+		params[name] = matches[index+1]
+	}
+
+	return params, true
+}
+
+type customIDRoute struct {
+	pattern *customIDPattern
+	handler any
+	naming  NamingStrategy
+}
+
+// validateStringArgs ensures every field of an args struct can be decoded from a raw string value.
+func validateStringArgs(argsType reflect.Type, naming NamingStrategy) error {
+	err := validateFieldNames(argsType, naming)
+	if err != nil {
+		return err
+	}
+
+	for index := 0; index < argsType.NumField(); index++ {
+		field := argsType.Field(index)
+
+		valueType := field.Type
+		if valueType.Kind() == reflect.Ptr {
+			valueType = valueType.Elem()
+		}
+		if !stringArgTypes[getBaseType(valueType)] {
+			return fmt.Errorf("unable to decode struct field %s: %w", field.Name, ErrInvalidArgumentType)
+		}
+	}
+
+	return nil
+}
+
+func validateComponentHandler(pattern *customIDPattern, handler any, naming NamingStrategy) error {
+	err := validateSlashCommand(handler)
+	if err != nil {
+		return err
+	}
+
+	argsType := getArgsType(handler)
+
+	err = validateStringArgs(argsType, naming)
+	if err != nil {
+		return err
+	}
+
+	for index := 0; index < argsType.NumField(); index++ {
+		field := argsType.Field(index)
+
+		_, hasDefault := field.Tag.Lookup("default")
+		if hasDefault || field.Type.Kind() == reflect.Ptr {
+			continue
+		}
+
+		found := false
+		for _, param := range pattern.params {
+			if param == getFieldName(field, naming) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf(
+				"%w: required field %s has no matching parameter in %s",
+				ErrInvalidCustomIDPattern,
+				field.Name,
+				pattern.pattern,
+			)
+		}
+	}
+
+	return nil
+}
+
+// AddComponentHandler registers a handler for message components (buttons, select menus, etc.) whose custom ID
+// matches the given pattern. Parameters captured by the pattern are decoded into the handler's args struct by name.
+// Patterns are checked in the order they were registered, and the first match is used.
+func (s *Switchboard) AddComponentHandler(pattern string, handler any) error {
+	compiled, err := compileCustomIDPattern(pattern)
+	if err != nil {
+		return err
+	}
+
+	naming := s.getNamingStrategy()
+
+	err = validateComponentHandler(compiled, handler, naming)
+	if err != nil {
+		return fmt.Errorf("invalid handler: %w", err)
+	}
+
+	s.componentRoutes = append(s.componentRoutes, &customIDRoute{pattern: compiled, handler: handler, naming: naming})
+
+	return nil
+}
+
+func (s *Switchboard) handleInteractionMessageComponent(invocation *Invocation) error {
+	session, interaction := invocation.Session, invocation.Interaction
+	customID := interaction.MessageComponentData().CustomID
+
+	for _, route := range s.componentRoutes {
+		params, matched := route.pattern.match(customID)
+		if !matched {
+			continue
+		}
+
+		args, err := decodeStringValues(params, getArgsType(route.handler), route.naming)
+		if err != nil {
+			return fmt.Errorf("error decoding custom ID %s: %w", customID, err)
+		}
+
+		return callHandler(invocation.Context, route.handler, session, interaction, args)
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnknownComponent, customID)
+}
@@ -0,0 +1,152 @@
+package switchboard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func Test_compileCustomIDPattern_WithParams(t *testing.T) {
+	pattern, err := compileCustomIDPattern("vote:{poll}:{choice}")
+	if err != nil {
+		t.Errorf("got unexpected error compiling pattern: %s", err)
+	}
+
+	params, matched := pattern.match("vote:123:yes")
+	if !matched {
+		t.Error("pattern did not match expected custom ID")
+	}
+	if diff := deep.Equal(params, map[string]string{"poll": "123", "choice": "yes"}); diff != nil {
+		t.Error(diff)
+	}
+
+	if _, matched = pattern.match("vote:123"); matched {
+		t.Error("pattern matched unexpected custom ID")
+	}
+	if _, matched = pattern.match("vote:123:yes:extra"); !matched {
+		t.Error("pattern did not match custom ID with separator in final parameter")
+	}
+}
+
+func Test_compileCustomIDPattern_WithPrefix(t *testing.T) {
+	pattern, err := compileCustomIDPattern("page:{page}:*")
+	if err != nil {
+		t.Errorf("got unexpected error compiling pattern: %s", err)
+	}
+
+	params, matched := pattern.match("page:2:some-state")
+	if !matched {
+		t.Error("pattern did not match expected custom ID")
+	}
+	if diff := deep.Equal(params, map[string]string{"page": "2"}); diff != nil {
+		t.Error(diff)
+	}
+
+	if _, matched = pattern.match("other:2:some-state"); matched {
+		t.Error("pattern matched unexpected custom ID")
+	}
+}
+
+func Test_compileCustomIDPattern_WithParameterBeforePrefix(t *testing.T) {
+	_, err := compileCustomIDPattern("item:{id}*")
+
+	if !errors.Is(err, ErrInvalidCustomIDPattern) {
+		t.Errorf("got unexpected error compiling pattern: %v", err)
+	}
+
+	pattern, err := compileCustomIDPattern("item:{id}:*")
+	if err != nil {
+		t.Fatalf("got unexpected error compiling pattern: %s", err)
+	}
+
+	params, matched := pattern.match("item:12345:some-state")
+	if !matched {
+		t.Error("pattern did not match expected custom ID")
+	}
+	if diff := deep.Equal(params, map[string]string{"id": "12345"}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func Test_compileCustomIDPattern_WithInvalidPatterns(t *testing.T) {
+	for _, pattern := range []string{"", "vote:{poll", "vote:{}", "vote:{poll}:{poll}", "vote:{poll id}"} {
+		_, err := compileCustomIDPattern(pattern)
+
+		if err == nil {
+			t.Errorf("did not get expected error compiling pattern %q", pattern)
+		}
+		if !errors.Is(err, ErrInvalidCustomIDPattern) {
+			t.Errorf("got unexpected error compiling pattern %q: %s", pattern, err)
+		}
+	}
+}
+
+func TestSwitchboard_AddComponentHandler_WithUnmatchedField(t *testing.T) {
+	s := &Switchboard{}
+
+	err := s.AddComponentHandler("vote:{poll}", func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct {
+		Poll   int
+		Choice string
+	}) {
+	})
+
+	if err == nil {
+		t.Error("did not get expected error")
+	}
+	if !errors.Is(err, ErrInvalidCustomIDPattern) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
+
+func TestSwitchboard_handleInteractionMessageComponent(t *testing.T) {
+	s := &Switchboard{}
+	called := false
+
+	type Args struct {
+		Poll   int
+		Choice string
+		Page   *uint
+		Source string `default:"button"`
+	}
+
+	err := s.AddComponentHandler("vote:{poll}:{choice}", func(
+		_ *discordgo.Session,
+		_ *discordgo.InteractionCreate,
+		args Args,
+	) {
+		called = true
+
+		if diff := deep.Equal(args, Args{Poll: 123, Choice: "yes", Source: "button"}); diff != nil {
+			t.Error(diff)
+		}
+	})
+	if err != nil {
+		t.Errorf("got unexpected error adding component handler: %s", err)
+	}
+
+	err = s.handleInteractionMessageComponent(&Invocation{Interaction: &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionMessageComponent,
+			Data: discordgo.MessageComponentInteractionData{CustomID: "vote:123:yes"},
+		},
+	}})
+	if err != nil {
+		t.Errorf("got unexpected error handling component: %s", err)
+	}
+
+	if !called {
+		t.Error("handler function not called")
+	}
+
+	err = s.handleInteractionMessageComponent(&Invocation{Interaction: &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionMessageComponent,
+			Data: discordgo.MessageComponentInteractionData{CustomID: "unknown"},
+		},
+	}})
+	if !errors.Is(err, ErrUnknownComponent) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,147 @@
+package switchboard
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord limits string options to 6000 characters.
+const maxOptionLength = 6000
+
+func parseFloatTag(field reflect.StructField, tag string) (*float64, error) {
+	raw, hasTag := field.Tag.Lookup(tag)
+	if !hasTag {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, fmt.Errorf("%w: %s tag must be a number, got %q", ErrInvalidConstraint, tag, raw)
+	}
+
+	return &value, nil
+}
+
+func parseLengthTag(field reflect.StructField, tag string, limit int) (*int, error) {
+	raw, hasTag := field.Tag.Lookup(tag)
+	if !hasTag {
+		return nil, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 || value > limit {
+		return nil, fmt.Errorf(
+			"%w: %s tag must be an integer between 0 and %d, got %q",
+			ErrInvalidConstraint,
+			tag,
+			limit,
+			raw,
+		)
+	}
+
+	return &value, nil
+}
+
+// parseLengthConstraints parses and checks the minLength and maxLength tags of a field, each of which must be
+// between 0 and limit.
+func parseLengthConstraints(field reflect.StructField, limit int) (*int, *int, error) {
+	minLength, err := parseLengthTag(field, "minLength", limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	maxLength, err := parseLengthTag(field, "maxLength", limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if maxLength != nil {
+		if *maxLength < 1 {
+			return nil, nil, fmt.Errorf("%w: maxLength must be at least 1", ErrInvalidConstraint)
+		}
+		if minLength != nil && *maxLength < *minLength {
+			return nil, nil, fmt.Errorf("%w: maxLength must not be less than minLength", ErrInvalidConstraint)
+		}
+	}
+
+	return minLength, maxLength, nil
+}
+
+// applyValueConstraints sets MinValue and MaxValue on an integer or number option from its min and max tags.
+func applyValueConstraints(field reflect.StructField, option *discordgo.ApplicationCommandOption) error {
+	minValue, err := parseFloatTag(field, "min")
+	if err != nil {
+		return err
+	}
+
+	maxValue, err := parseFloatTag(field, "max")
+	if err != nil {
+		return err
+	}
+
+	if minValue == nil && maxValue == nil {
+		return nil
+	}
+
+	if option.Type != discordgo.ApplicationCommandOptionInteger &&
+		option.Type != discordgo.ApplicationCommandOptionNumber {
+		return fmt.Errorf("%w: min and max tags require an integer or number option", ErrConstraintUnsupportedType)
+	}
+
+	if option.Type == discordgo.ApplicationCommandOptionInteger {
+		for _, value := range []*float64{minValue, maxValue} {
+			if value != nil && *value != math.Trunc(*value) {
+				return fmt.Errorf("%w: min and max of integer options must be integers", ErrInvalidConstraint)
+			}
+		}
+	}
+
+	if minValue != nil {
+		// Unsigned options are already limited to a minimum of 0
+		if option.MinValue != nil && *minValue < *option.MinValue {
+			return fmt.Errorf("%w: min of unsigned option must not be negative", ErrInvalidConstraint)
+		}
+		option.MinValue = minValue
+	}
+
+	if maxValue != nil {
+		// MaxValue is omitted from the option's JSON when it is 0, so a maximum of 0 can't be expressed
+		if *maxValue == 0 {
+			return fmt.Errorf("%w: max of 0 is not supported", ErrInvalidConstraint)
+		}
+		if option.MinValue != nil && *maxValue < *option.MinValue {
+			return fmt.Errorf("%w: max must not be less than min", ErrInvalidConstraint)
+		}
+		option.MaxValue = *maxValue
+	}
+
+	return nil
+}
+
+// applyLengthConstraints sets MinLength and MaxLength on a string option from its minLength and maxLength tags.
+func applyLengthConstraints(field reflect.StructField, option *discordgo.ApplicationCommandOption) error {
+	minLength, maxLength, err := parseLengthConstraints(field, maxOptionLength)
+	if err != nil {
+		return err
+	}
+
+	if minLength == nil && maxLength == nil {
+		return nil
+	}
+
+	if option.Type != discordgo.ApplicationCommandOptionString {
+		return fmt.Errorf("%w: minLength and maxLength tags require a string option", ErrConstraintUnsupportedType)
+	}
+
+	if maxLength != nil {
+		option.MaxLength = *maxLength
+	}
+
+	option.MinLength = minLength
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package switchboard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func Test_getCommandOptions_WithConstraints(t *testing.T) {
+	minInt := -5.0
+	minUint := 0.0
+	minLength := 2
+
+	options, err := getCommandOptions(
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+			Int   int     `description:"Int" min:"-5" max:"5"`
+			Uint  uint    `description:"Uint" max:"10"`
+			Float float64 `description:"Float" max:"0.5"`
+			Name  string  `description:"Name" minLength:"2" maxLength:"32"`
+		}) {
+		},
+		LowerCaseNaming,
+	)
+
+	if err != nil {
+		t.Errorf("got unexpected error getting command options: %s", err)
+	}
+
+	if diff := deep.Equal(
+		options,
+		[]*discordgo.ApplicationCommandOption{
+			{
+				Name:        "int",
+				Required:    true,
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Description: "Int",
+				MinValue:    &minInt,
+				MaxValue:    5,
+			},
+			{
+				Name:        "uint",
+				Required:    true,
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Description: "Uint",
+				MinValue:    &minUint,
+				MaxValue:    10,
+			},
+			{
+				Name:        "float",
+				Required:    true,
+				Type:        discordgo.ApplicationCommandOptionNumber,
+				Description: "Float",
+				MaxValue:    0.5,
+			},
+			{
+				Name:        "name",
+				Required:    true,
+				Type:        discordgo.ApplicationCommandOptionString,
+				Description: "Name",
+				MinLength:   &minLength,
+				MaxLength:   32,
+			},
+		},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func Test_getCommandOptions_WithInvalidConstraints(t *testing.T) {
+	tests := map[string]struct {
+		handler  any
+		expected error
+	}{
+		"min greater than max": {
+			handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+				Int int `description:"Int" min:"10" max:"5"`
+			}) {
+			},
+			expected: ErrInvalidConstraint,
+		},
+		"negative min on uint": {
+			handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+				Uint uint `description:"Uint" min:"-1"`
+			}) {
+			},
+			expected: ErrInvalidConstraint,
+		},
+		"fractional min on int": {
+			handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+				Int int `description:"Int" min:"1.5"`
+			}) {
+			},
+			expected: ErrInvalidConstraint,
+		},
+		"unparsable max": {
+			handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+				Float float64 `description:"Float" max:"large"`
+			}) {
+			},
+			expected: ErrInvalidConstraint,
+		},
+		"minLength greater than maxLength": {
+			handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+				Name string `description:"Name" minLength:"10" maxLength:"5"`
+			}) {
+			},
+			expected: ErrInvalidConstraint,
+		},
+		"min on string": {
+			handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+				Name string `description:"Name" min:"1"`
+			}) {
+			},
+			expected: ErrConstraintUnsupportedType,
+		},
+		"maxLength on int": {
+			handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+				Int int `description:"Int" maxLength:"1"`
+			}) {
+			},
+			expected: ErrConstraintUnsupportedType,
+		},
+	}
+
+	for name, test := range tests {
+		_, err := getCommandOptions(test.handler, LowerCaseNaming)
+
+		if err == nil {
+			t.Errorf("%s: did not get expected error when getting command options", name)
+		}
+		if !errors.Is(err, test.expected) {
+			t.Errorf("%s: got unexpected error when getting command options: %s", name, err)
+		}
+	}
+}
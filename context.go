@@ -0,0 +1,172 @@
+package switchboard
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// interactionTokenLifetime is how long an interaction's token remains valid for after the interaction is created.
+const interactionTokenLifetime = 15 * time.Minute
+
+// initialResponseWindow is how long Discord waits for the initial response to an interaction after it is created.
+const initialResponseWindow = 3 * time.Second
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+type responseDeadlineKey struct{}
+
+// ResponseDeadline returns the time by which the initial response must be sent for the interaction being handled
+// with the given context. The context's own deadline is the expiry of the interaction token, after which no further
+// responses or followups may be sent.
+func ResponseDeadline(ctx context.Context) (time.Time, bool) {
+	deadline, ok := ctx.Value(responseDeadlineKey{}).(time.Time)
+	return deadline, ok
+}
+
+// getInteractionCreatedAt determines when an interaction was created from its ID, falling back to the current time if
+// the ID is not a valid snowflake.
+func getInteractionCreatedAt(interaction *discordgo.InteractionCreate) time.Time {
+	if interaction.Interaction == nil {
+		return time.Now()
+	}
+
+	createdAt, err := discordgo.SnowflakeTimestamp(interaction.ID)
+	if err != nil || interaction.ID == "" {
+		return time.Now()
+	}
+
+	return createdAt
+}
+
+// baseContext returns the context from which every interaction context is derived, which is cancelled when the
+// Switchboard is closed.
+func (s *Switchboard) baseContext() context.Context {
+	s.contextOnce.Do(func() {
+		s.ctx, s.cancel = context.WithCancel(context.Background())
+	})
+
+	return s.ctx
+}
+
+// newInteractionContext creates the context passed to handlers for an interaction.
+func (s *Switchboard) newInteractionContext(
+	interaction *discordgo.InteractionCreate,
+) (context.Context, context.CancelFunc) {
+	createdAt := getInteractionCreatedAt(interaction)
+
+	ctx := context.WithValue(s.baseContext(), responseDeadlineKey{}, createdAt.Add(initialResponseWindow))
+
+	return context.WithDeadline(ctx, createdAt.Add(interactionTokenLifetime))
+}
+
+// Close shuts down the Switchboard, cancelling the contexts of any interactions which are still being handled, as
+// well as those of any interactions handled afterwards.
+func (s *Switchboard) Close() {
+	s.baseContext()
+	s.cancel()
+}
+
+var switchboardContextType = reflect.TypeOf(&Context{})
+
+// Context is the context for an interaction, providing helpers for responding to it. Handlers may accept a *Context
+// in place of a context.Context as their first parameter.
+//
+// The initial response to an interaction may only be sent once - helpers which send it return ErrAlreadyResponded if
+// it has already been sent, rather than the request being rejected by Discord.
+type Context struct {
+	context.Context
+
+	session     *discordgo.Session
+	interaction *discordgo.InteractionCreate
+	responder   *responder
+}
+
+// newContext creates a Context for an interaction, reusing the response state tracked by ctx if it has any.
+func newContext(
+	ctx context.Context,
+	session *discordgo.Session,
+	interaction *discordgo.InteractionCreate,
+) *Context {
+	r, err := getResponder(ctx)
+	if err != nil {
+		r = newResponder(session, interaction)
+		ctx = context.WithValue(ctx, responderKey{}, r)
+	}
+
+	return &Context{Context: ctx, session: session, interaction: interaction, responder: r}
+}
+
+// Respond sends the initial response to the interaction. If the response has already been deferred, message responses
+// are instead sent as an edit of the deferred response.
+func (c *Context) Respond(response *discordgo.InteractionResponse) error {
+	return c.responder.respond(response)
+}
+
+// Reply responds to the interaction with a message.
+func (c *Context) Reply(data *discordgo.InteractionResponseData) error {
+	return c.Respond(&discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+}
+
+// ReplyEphemeral responds to the interaction with a message visible only to the user who invoked it.
+func (c *Context) ReplyEphemeral(data *discordgo.InteractionResponseData) error {
+	var ephemeralData discordgo.InteractionResponseData
+	if data != nil {
+		ephemeralData = *data
+	}
+	ephemeralData.Flags |= discordgo.MessageFlagsEphemeral
+
+	return c.Reply(&ephemeralData)
+}
+
+// Defer acknowledges the interaction without sending a message, allowing one to be sent later using Reply or
+// EditReply.
+func (c *Context) Defer(ephemeral bool) error {
+	return c.responder.deferResponse(ephemeral)
+}
+
+// EditReply edits the initial response to the interaction.
+func (c *Context) EditReply(edit *discordgo.WebhookEdit) (*discordgo.Message, error) {
+	err := c.responder.checkResponded()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.session.InteractionResponseEdit(c.interaction.Interaction, edit)
+}
+
+// Followup sends an additional message in response to the interaction, after the initial response has been sent.
+func (c *Context) Followup(params *discordgo.WebhookParams) (*discordgo.Message, error) {
+	err := c.responder.checkResponded()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.session.FollowupMessageCreate(c.interaction.Interaction, true, params)
+}
+
+// DeleteReply deletes the initial response to the interaction.
+func (c *Context) DeleteReply() error {
+	err := c.responder.checkResponded()
+	if err != nil {
+		return err
+	}
+
+	return c.session.InteractionResponseDelete(c.interaction.Interaction)
+}
+
+// ShowModal responds to the interaction with a modal built from fields, as with NewModalResponse. Custom IDs are
+// derived from field names using the NamingStrategy of the Switchboard handling the interaction.
+func (c *Context) ShowModal(customID string, title string, fields any) error {
+	response, err := newModalResponse(customID, title, fields, getContextNamingStrategy(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Respond(response)
+}
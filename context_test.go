@@ -0,0 +1,279 @@
+package switchboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+type contextKey struct{}
+
+func Test_validateHandler_SlashCommand_WithContext(t *testing.T) {
+	err := validateHandler(
+		SlashCommand,
+		func(_ context.Context, _ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {},
+	)
+	if err != nil {
+		t.Errorf("got unexpected error when validating handler: %s", err)
+	}
+}
+
+func Test_validateHandler_SlashCommand_WithContextAndWrongArgCount(t *testing.T) {
+	err := validateHandler(SlashCommand, func(_ context.Context, _ *discordgo.Session, _ *discordgo.InteractionCreate) {})
+	if !errors.Is(err, ErrHandlerInvalidParameterCount) {
+		t.Errorf("got unexpected error when validating handler: %v", err)
+	}
+}
+
+func Test_validateHandler_UserCommand_WithContext(t *testing.T) {
+	err := validateHandler(
+		UserCommand,
+		func(
+			_ context.Context,
+			_ *discordgo.Session,
+			_ *discordgo.InteractionCreate,
+			_ *discordgo.User,
+			_ *discordgo.Member,
+		) {
+		},
+	)
+	if err != nil {
+		t.Errorf("got unexpected error when validating handler: %s", err)
+	}
+}
+
+func Test_invokeCommand_SlashCommand_WithContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextKey{}, "value")
+	var receivedValue any
+	var receivedArgs struct {
+		Name string `description:"Name"`
+	}
+
+	err := invokeCommand(
+		ctx,
+		&Command{Type: SlashCommand},
+		&discordgo.Session{},
+		&discordgo.InteractionCreate{
+			Interaction: &discordgo.Interaction{
+				Type: discordgo.InteractionApplicationCommand,
+				Data: discordgo.ApplicationCommandInteractionData{
+					Options: []*discordgo.ApplicationCommandInteractionDataOption{
+						{Name: "name", Type: discordgo.ApplicationCommandOptionString, Value: "test"},
+					},
+				},
+			},
+		},
+		func(ctx context.Context, _ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+			Name string `description:"Name"`
+		}) {
+			receivedValue = ctx.Value(contextKey{})
+			receivedArgs = args
+		},
+	)
+	if err != nil {
+		t.Errorf("got unexpected error: %s", err)
+	}
+
+	if receivedValue != "value" {
+		t.Errorf("handler received unexpected context value: %v", receivedValue)
+	}
+	if receivedArgs.Name != "test" {
+		t.Errorf("handler received unexpected args: %#v", receivedArgs)
+	}
+}
+
+func TestSwitchboard_HandleInteractionCreate_WithContext(t *testing.T) {
+	createdAt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Snowflakes store milliseconds since the Discord epoch in their upper bits
+	interactionID := strconv.FormatInt((createdAt.UnixMilli()-1420070400000)<<22, 10)
+
+	var receivedContext context.Context
+
+	s := &Switchboard{}
+	s.Use(func(next InvocationHandler) InvocationHandler {
+		return func(invocation *Invocation) error {
+			invocation.Context = context.WithValue(invocation.Context, contextKey{}, "value")
+			return next(invocation)
+		}
+	})
+	_ = s.AddCommand(&Command{
+		Name:        "test",
+		Description: "Test command",
+		Handler: func(ctx context.Context, _ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {
+			receivedContext = ctx
+		},
+	})
+
+	s.HandleInteractionCreate(nil, &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			ID:   interactionID,
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{Name: "test"},
+		},
+	})
+
+	if receivedContext == nil {
+		t.Fatal("handler function not called")
+	}
+
+	if receivedContext.Value(contextKey{}) != "value" {
+		t.Error("context value set by middleware was not passed to handler")
+	}
+
+	deadline, ok := receivedContext.Deadline()
+	if !ok || !deadline.Equal(createdAt.Add(15*time.Minute)) {
+		t.Errorf("got unexpected context deadline: %s", deadline)
+	}
+
+	responseDeadline, ok := ResponseDeadline(receivedContext)
+	if !ok || !responseDeadline.Equal(createdAt.Add(3*time.Second)) {
+		t.Errorf("got unexpected response deadline: %s", responseDeadline)
+	}
+
+	if receivedContext.Err() == nil {
+		t.Error("context was not cancelled after handler returned")
+	}
+}
+
+func TestSwitchboard_Close(t *testing.T) {
+	var contextErr error
+
+	s := &Switchboard{}
+	_ = s.AddComponentHandler(
+		"button",
+		func(ctx context.Context, _ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {
+			contextErr = ctx.Err()
+		},
+	)
+
+	s.Close()
+
+	s.HandleInteractionCreate(nil, &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionMessageComponent,
+			Data: discordgo.MessageComponentInteractionData{CustomID: "button"},
+		},
+	})
+
+	if !errors.Is(contextErr, context.Canceled) {
+		t.Errorf("got unexpected context error: %v", contextErr)
+	}
+}
+
+func Test_validateHandler_SlashCommand_WithSwitchboardContext(t *testing.T) {
+	err := validateHandler(
+		SlashCommand,
+		func(_ *Context, _ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {},
+	)
+	if err != nil {
+		t.Errorf("got unexpected error when validating handler: %s", err)
+	}
+}
+
+func TestContext(t *testing.T) {
+	session, getRequests := newTestSession(t, nil)
+	var errs []error
+
+	s := &Switchboard{}
+	_ = s.AddCommand(&Command{
+		Name:        "test",
+		Description: "Test command",
+		Handler: func(ctx *Context, _ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {
+			_, err := ctx.EditReply(&discordgo.WebhookEdit{})
+			errs = append(errs, err)
+
+			errs = append(errs, ctx.ReplyEphemeral(&discordgo.InteractionResponseData{Content: "Hello world!"}))
+			errs = append(errs, ctx.Reply(&discordgo.InteractionResponseData{Content: "Hello again!"}))
+			errs = append(errs, ctx.Defer(false))
+
+			_, err = ctx.Followup(&discordgo.WebhookParams{Content: "Followup"})
+			errs = append(errs, err)
+
+			errs = append(errs, ctx.DeleteReply())
+		},
+	})
+
+	s.HandleInteractionCreate(session, newTestCommandInteraction("test"))
+
+	if len(errs) != 6 {
+		t.Fatal("handler function not called")
+	}
+	for index, expected := range []error{ErrNotResponded, nil, ErrAlreadyResponded, ErrAlreadyResponded, nil, nil} {
+		if !errors.Is(errs[index], expected) {
+			t.Errorf("got unexpected error for call %d: %v", index, errs[index])
+		}
+	}
+
+	requests := getRequests()
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(requests))
+	}
+
+	if diff := deep.Equal(
+		[]any{
+			requests[0].Path,
+			requests[0].Body["data"].(map[string]any)["flags"],
+			requests[1].Path,
+			requests[1].Body["content"],
+			requests[2].Method,
+			requests[2].Path,
+		},
+		[]any{
+			"/api/v9/interactions/1/token/callback",
+			float64(discordgo.MessageFlagsEphemeral),
+			"/api/v9/webhooks/2/token",
+			"Followup",
+			http.MethodDelete,
+			"/api/v9/webhooks/2/token/messages/@original",
+		},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestContext_ShowModal(t *testing.T) {
+	session, getRequests := newTestSession(t, nil)
+	var showErr error
+
+	s := &Switchboard{}
+	_ = s.AddComponentHandler(
+		"button",
+		func(ctx *Context, _ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {
+			showErr = ctx.ShowModal("feedback", "Feedback", struct {
+				Comment string
+			}{})
+		},
+	)
+
+	s.HandleInteractionCreate(session, &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			ID:    "1",
+			AppID: "2",
+			Token: "token",
+			Type:  discordgo.InteractionMessageComponent,
+			Data:  discordgo.MessageComponentInteractionData{CustomID: "button"},
+		},
+	})
+
+	if showErr != nil {
+		t.Errorf("got unexpected error when showing modal: %s", showErr)
+	}
+
+	requests := getRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+
+	if diff := deep.Equal(
+		[]any{requests[0].Body["type"], requests[0].Body["data"].(map[string]any)["custom_id"]},
+		[]any{float64(discordgo.InteractionResponseModal), "feedback"},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -22,11 +22,69 @@ var ErrHandlerInvalidSecondParameterType = errors.New(
 var ErrHandlerInvalidThirdParameterType = errors.New(
 	"incorrect third parameter type for handler - third parameter must be of type struct",
 )
+var ErrHandlerInvalidReturnType = errors.New(
+	"incorrect return type for handler - handler must return either nothing or an error",
+)
 var ErrMessageHandlerInvalidThirdParameterType = errors.New(
 	"incorrect third parameter type for handler - third parameter must be of type *discordgo.Message",
 )
+var ErrUserHandlerInvalidThirdParameterType = errors.New(
+	"incorrect third parameter type for handler - third parameter must be of type *discordgo.User",
+)
+var ErrUserHandlerInvalidFourthParameterType = errors.New(
+	"incorrect fourth parameter type for handler - fourth parameter must be of type *discordgo.Member",
+)
 var ErrUnknownCommand = errors.New("unknown command")
 var ErrUnsupportedInteractionType = errors.New("unsupported interaction type")
 var ErrUnsupportedDefaultArgType = errors.New(
 	"attempted to use default value for option type which does not currently support default values",
 )
+var ErrUnknownSubCommand = errors.New("unknown subcommand")
+var ErrSubCommandsWithHandler = errors.New(
+	"commands with subcommands or subcommand groups must not have their own handler",
+)
+var ErrSubCommandsOnNonSlashCommand = errors.New("only slash commands may have subcommands")
+var ErrEmptySubCommandGroup = errors.New("subcommand groups must contain at least one subcommand")
+var ErrDuplicateSubCommandName = errors.New("multiple subcommands or subcommand groups share the same name")
+var ErrUnknownOption = errors.New("unknown option")
+var ErrNoFocusedOption = errors.New("autocomplete interaction has no focused option")
+var ErrUnknownAutocompleteProvider = errors.New("unknown autocomplete provider")
+var ErrDuplicateAutocompleteProvider = errors.New("autocomplete provider is already registered")
+var ErrAutocompleteUnsupportedType = errors.New(
+	"autocomplete is only supported for string, integer and number options",
+)
+var ErrMissingValue = errors.New("no value provided for required field")
+var ErrInvalidCustomIDPattern = errors.New("invalid custom ID pattern")
+var ErrUnknownComponent = errors.New("no handler registered for component custom ID")
+var ErrUnknownModal = errors.New("no handler registered for modal custom ID")
+var ErrModalFieldsNotStruct = errors.New("modal fields must be a struct or a pointer to a struct")
+var ErrInvalidTextInputStyle = errors.New("text input style must be one of short or paragraph")
+var ErrInvalidTextInputLabel = errors.New("invalid text input label")
+var ErrTooManyModalTextInputs = errors.New("modals may contain at most 5 text inputs")
+var ErrChoicesUnsupportedType = errors.New("choices are only supported for string, integer and number options")
+var ErrChoicesWithAutocomplete = errors.New("options cannot have both choices and autocomplete")
+var ErrNoChoices = errors.New("options with choices must have at least one choice")
+var ErrTooManyChoices = errors.New("options may have at most 25 choices")
+var ErrInvalidChoiceValue = errors.New("choice value does not match option type")
+var ErrInvalidConstraint = errors.New("invalid option constraint")
+var ErrConstraintUnsupportedType = errors.New("option constraint is not supported for this option type")
+var ErrInvalidChannelType = errors.New("invalid channel type")
+var ErrDisallowedChannelType = errors.New("provided channel is not of an allowed type")
+var ErrChannelTypesUnsupportedType = errors.New("channel types can only be restricted for channel options")
+var ErrUnresolvedValue = errors.New("option value could not be resolved from interaction data")
+var ErrMemberOptionInDMs = errors.New(
+	"member options are only supported on guild commands or global commands with DMPermission disabled",
+)
+var ErrOptionTypeMismatch = errors.New("provided option does not match the type of its struct field")
+var ErrUnsupportedOptionType = errors.New("unsupported option type")
+var ErrInvalidOptionName = errors.New("invalid option name")
+var ErrDuplicateOptionName = errors.New("multiple fields share the same option name")
+var ErrNoInteractionContext = errors.New("context was not created by a Switchboard for an interaction")
+var ErrAlreadyResponded = errors.New("interaction has already been responded to")
+var ErrResponseAfterDefer = errors.New("only message responses can be sent after deferring")
+var ErrNotResponded = errors.New("interaction has not been responded to")
+var ErrInvalidCommandName = errors.New("invalid command name")
+var ErrInvalidDescription = errors.New("invalid description")
+var ErrTooManyOptions = errors.New("commands may have at most 25 options")
+var ErrRequiredOptionAfterOptional = errors.New("required options must come before optional options")
+var ErrDuplicateCommand = errors.New("a command with the same name and type is already registered in this scope")
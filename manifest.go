@@ -0,0 +1,121 @@
+package switchboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Manifest is the full set of commands generated by a Switchboard, grouped by guild scope. It can be written to and
+// read from JSON, allowing commands to be reviewed and synced without the handlers being available.
+type Manifest struct {
+	// Every scope, in order of guild ID, with global commands first.
+	Scopes []*ManifestScope `json:"scopes"`
+}
+
+// ManifestScope is the set of commands registered in a single scope.
+type ManifestScope struct {
+	// The ID of the guild the scope is for, or an empty string for global commands.
+	GuildID string `json:"guild_id"`
+
+	// The commands registered in the scope, in order of type and then name.
+	Commands []*discordgo.ApplicationCommand `json:"commands"`
+}
+
+// Manifest generates the manifest of every command registered with the Switchboard. The manifest is deterministic,
+// regardless of the order commands were registered in.
+func (s *Switchboard) Manifest() (*Manifest, error) {
+	guildCommands, err := s.getDiscordCommands()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+
+	for _, guildID := range getSortedGuildIDs(guildCommands) {
+		commands := guildCommands[guildID]
+		sort.SliceStable(commands, func(i, j int) bool {
+			keyI, keyJ := getCommandKey(commands[i]), getCommandKey(commands[j])
+			if keyI.Type != keyJ.Type {
+				return keyI.Type < keyJ.Type
+			}
+			return keyI.Name < keyJ.Name
+		})
+
+		manifest.Scopes = append(manifest.Scopes, &ManifestScope{GuildID: guildID, Commands: commands})
+	}
+
+	return manifest, nil
+}
+
+// WriteJSON writes the manifest as indented JSON.
+func (m *Manifest) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(m)
+}
+
+// ReadManifest reads a manifest previously written by WriteJSON.
+func ReadManifest(r io.Reader) (*Manifest, error) {
+	manifest := &Manifest{}
+
+	err := json.NewDecoder(r).Decode(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// getGuildCommands returns the commands in the manifest keyed by guild ID. Only scopes listed in the manifest are
+// included, so that scopes it doesn't mention are left untouched.
+func (m *Manifest) getGuildCommands() map[string][]*discordgo.ApplicationCommand {
+	guildCommands := map[string][]*discordgo.ApplicationCommand{}
+
+	for _, scope := range m.Scopes {
+		commands := scope.Commands
+		if commands == nil {
+			commands = []*discordgo.ApplicationCommand{}
+		}
+		guildCommands[scope.GuildID] = append(guildCommands[scope.GuildID], commands...)
+	}
+
+	return guildCommands
+}
+
+// PlanManifest determines the changes SyncManifest would make, without writing anything to Discord.
+func PlanManifest(
+	session *discordgo.Session,
+	appId string,
+	manifest *Manifest,
+	prune *PruneOptions,
+) (*SyncReport, error) {
+	guildCommands := manifest.getGuildCommands()
+	addPrunedScopes(session, prune, guildCommands)
+
+	return planCommands(session, appId, guildCommands)
+}
+
+// SyncManifest registers the commands in a manifest with Discord, in the same way as SyncCommands, without requiring
+// a Switchboard with the commands' handlers. If prune is not nil, the scopes it specifies are also checked, and any
+// commands in them which are not in the manifest are removed.
+func SyncManifest(
+	session *discordgo.Session,
+	appId string,
+	manifest *Manifest,
+	prune *PruneOptions,
+) (*SyncReport, error) {
+	guildCommands := manifest.getGuildCommands()
+	addPrunedScopes(session, prune, guildCommands)
+
+	report, err := planCommands(session, appId, guildCommands)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, applyPlan(session, appId, report, guildCommands)
+}
@@ -0,0 +1,139 @@
+package switchboard
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func TestSwitchboard_Manifest(t *testing.T) {
+	pingHandler := func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {}
+	userHandler := func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ *discordgo.User) {}
+
+	commands := []*Command{
+		{Name: "ping", Description: "Ping", Handler: pingHandler, GuildID: "2"},
+		{Name: "Profile", Handler: userHandler, Type: UserCommand},
+		{Name: "pong", Description: "Pong", Handler: pingHandler},
+		{Name: "info", Description: "Info", Handler: pingHandler},
+	}
+
+	var outputs []string
+	for _, order := range [][]int{{0, 1, 2, 3}, {3, 2, 1, 0}} {
+		s := &Switchboard{}
+		for _, index := range order {
+			err := s.AddCommand(commands[index])
+			if err != nil {
+				t.Fatalf("got unexpected error adding command: %s", err)
+			}
+		}
+
+		manifest, err := s.Manifest()
+		if err != nil {
+			t.Fatalf("got unexpected error generating manifest: %s", err)
+		}
+
+		var names [][]string
+		for _, scope := range manifest.Scopes {
+			var scopeNames []string
+			for _, command := range scope.Commands {
+				scopeNames = append(scopeNames, scope.GuildID+":"+command.Name)
+			}
+			names = append(names, scopeNames)
+		}
+
+		if diff := deep.Equal(names, [][]string{{":info", ":pong", ":Profile"}, {"2:ping"}}); diff != nil {
+			t.Error(diff)
+		}
+
+		var buffer bytes.Buffer
+		err = manifest.WriteJSON(&buffer)
+		if err != nil {
+			t.Fatalf("got unexpected error writing manifest: %s", err)
+		}
+		outputs = append(outputs, buffer.String())
+	}
+
+	if outputs[0] != outputs[1] {
+		t.Errorf("manifest output depends on registration order:\n%s\n%s", outputs[0], outputs[1])
+	}
+}
+
+func TestSyncManifest(t *testing.T) {
+	manifest, err := newSyncTestSwitchboard(t).Manifest()
+	if err != nil {
+		t.Fatalf("got unexpected error generating manifest: %s", err)
+	}
+
+	var buffer bytes.Buffer
+	err = manifest.WriteJSON(&buffer)
+	if err != nil {
+		t.Fatalf("got unexpected error writing manifest: %s", err)
+	}
+
+	imported, err := ReadManifest(&buffer)
+	if err != nil {
+		t.Fatalf("got unexpected error reading manifest: %s", err)
+	}
+
+	session, getRequests := newTestSession(t, respondWithRemoteCommands)
+
+	report, err := SyncManifest(session, "app", imported, nil)
+	if err != nil {
+		t.Fatalf("got unexpected error syncing manifest: %s", err)
+	}
+
+	var writes []string
+	for _, request := range getRequests() {
+		if request.Method != http.MethodGet {
+			writes = append(writes, request.Method+" "+request.Path)
+		}
+	}
+
+	if diff := deep.Equal(writes, []string{"PUT /api/v9/applications/app/guilds/1/commands"}); diff != nil {
+		t.Error(diff)
+	}
+
+	if len(report.Changed()) != 1 || report.Changed()[0].GuildID != "1" {
+		t.Errorf("got unexpected report:\n%s", report)
+	}
+}
+
+func TestSyncManifest_WithOnlyGuildScopes(t *testing.T) {
+	manifest := &Manifest{
+		Scopes: []*ManifestScope{
+			{
+				GuildID: "1",
+				Commands: []*discordgo.ApplicationCommand{
+					{Name: "echo", Description: "Echo some text", Type: discordgo.ChatApplicationCommand},
+				},
+			},
+		},
+	}
+
+	session, getRequests := newTestSession(t, respondWithRemoteCommands)
+
+	report, err := SyncManifest(session, "app", manifest, nil)
+	if err != nil {
+		t.Fatalf("got unexpected error syncing manifest: %s", err)
+	}
+
+	for _, request := range getRequests() {
+		if request.Path == "/api/v9/applications/app/commands" {
+			t.Errorf("expected global commands to be left alone, got %s %s", request.Method, request.Path)
+		}
+	}
+
+	if len(report.Scopes) != 1 || report.Scopes[0].GuildID != "1" {
+		t.Errorf("got unexpected report:\n%s", report)
+	}
+}
+
+func TestReadManifest_WithInvalidJSON(t *testing.T) {
+	_, err := ReadManifest(bytes.NewBufferString("{"))
+	if err == nil {
+		t.Error("expected error reading invalid manifest")
+	}
+}
@@ -0,0 +1,31 @@
+package switchboard
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Mentionable is the value of a mentionable option, which may refer to either a user or a role. Exactly one of User or
+// Role will be set, and Member is additionally set when a user is mentioned within a guild.
+type Mentionable struct {
+	User   *discordgo.User
+	Member *discordgo.Member
+	Role   *discordgo.Role
+}
+
+func resolveMentionable(
+	resolved *discordgo.ApplicationCommandInteractionDataResolved,
+	id string,
+	guildID string,
+) (Mentionable, error) {
+	if user := resolved.Users[id]; user != nil {
+		return Mentionable{User: user, Member: getResolvedMember(resolved, id, guildID)}, nil
+	}
+
+	if role := resolved.Roles[id]; role != nil {
+		return Mentionable{Role: role}, nil
+	}
+
+	return Mentionable{}, fmt.Errorf("%w: mentionable %s", ErrUnresolvedValue, id)
+}
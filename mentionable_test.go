@@ -0,0 +1,170 @@
+package switchboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func newMentionableInteraction(id string) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name:  "target",
+						Type:  discordgo.ApplicationCommandOptionMentionable,
+						Value: id,
+					},
+				},
+				Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+					Users: map[string]*discordgo.User{
+						"1": {ID: "1", Username: "Test"},
+					},
+					Members: map[string]*discordgo.Member{
+						"1": {Nick: "Tester"},
+					},
+					Roles: map[string]*discordgo.Role{
+						"2": {ID: "2", Name: "Moderators"},
+					},
+				},
+			},
+			GuildID: "3",
+		},
+	}
+}
+
+func Test_getCommandOptions_WithMentionableOption(t *testing.T) {
+	options, err := getCommandOptions(
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+			Target Mentionable `description:"Target"`
+		}) {
+		},
+		LowerCaseNaming,
+	)
+
+	if err != nil {
+		t.Errorf("got unexpected error getting command options: %s", err)
+	}
+
+	if diff := deep.Equal(
+		options,
+		[]*discordgo.ApplicationCommandOption{
+			{
+				Name:        "target",
+				Required:    true,
+				Type:        discordgo.ApplicationCommandOptionMentionable,
+				Description: "Target",
+			},
+		},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func Test_invokeCommand_SlashCommand_WithMentionedUser(t *testing.T) {
+	called := false
+
+	type Args struct {
+		Target Mentionable
+	}
+
+	err := invokeCommand(
+		context.Background(),
+		&Command{
+			Type: SlashCommand,
+		},
+		nil,
+		newMentionableInteraction("1"),
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args Args) {
+			called = true
+
+			if diff := deep.Equal(
+				args,
+				Args{
+					Target: Mentionable{
+						User: &discordgo.User{ID: "1", Username: "Test"},
+						Member: &discordgo.Member{
+							GuildID: "3",
+							Nick:    "Tester",
+							User:    &discordgo.User{ID: "1", Username: "Test"},
+						},
+					},
+				},
+			); diff != nil {
+				t.Error(diff)
+			}
+		})
+
+	if err != nil {
+		t.Errorf("got unexpected error: %s", err)
+	}
+	if !called {
+		t.Error("handler function not called")
+	}
+}
+
+func Test_invokeCommand_SlashCommand_WithMentionedRole(t *testing.T) {
+	called := false
+
+	type Args struct {
+		Target *Mentionable
+	}
+
+	err := invokeCommand(
+		context.Background(),
+		&Command{
+			Type: SlashCommand,
+		},
+		nil,
+		newMentionableInteraction("2"),
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args Args) {
+			called = true
+
+			if diff := deep.Equal(
+				args,
+				Args{
+					Target: &Mentionable{
+						Role: &discordgo.Role{ID: "2", Name: "Moderators"},
+					},
+				},
+			); diff != nil {
+				t.Error(diff)
+			}
+		})
+
+	if err != nil {
+		t.Errorf("got unexpected error: %s", err)
+	}
+	if !called {
+		t.Error("handler function not called")
+	}
+}
+
+func Test_invokeCommand_SlashCommand_WithUnresolvedMentionable(t *testing.T) {
+	type Args struct {
+		Target Mentionable
+	}
+
+	err := invokeCommand(
+		context.Background(),
+		&Command{
+			Type: SlashCommand,
+		},
+		nil,
+		newMentionableInteraction("4"),
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args Args) {
+			t.Error("handler function unexpectedly called")
+		})
+
+	if err == nil {
+		t.Error("did not get expected error")
+	}
+	if !errors.Is(err, ErrUnresolvedValue) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,43 @@
+package switchboard
+
+import (
+	"context"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Invocation describes an interaction being dispatched by a Switchboard.
+type Invocation struct {
+	// The context passed to handlers for the interaction. Middleware may replace it to attach request-scoped values.
+	Context context.Context
+
+	Session     *discordgo.Session
+	Interaction *discordgo.InteractionCreate
+
+	// The command targeted by the interaction, or nil for interactions which do not target a command, such as message
+	// components and modal submissions.
+	Command *Command
+}
+
+// InvocationHandler handles an Invocation, returning any error which occurred.
+type InvocationHandler func(invocation *Invocation) error
+
+// Middleware wraps the handling of an Invocation. Middleware may inspect or modify the invocation before calling
+// next, observe the error it returns, or short-circuit handling entirely by not calling next.
+type Middleware func(next InvocationHandler) InvocationHandler
+
+// Use adds middleware which will wrap the handling of every interaction dispatched by the Switchboard, including those
+// which fail to be routed, such as interactions for unknown commands. Middleware is applied in the order it is added,
+// with the first middleware being the outermost.
+func (s *Switchboard) Use(middleware ...Middleware) {
+	s.middleware = append(s.middleware, middleware...)
+}
+
+// applyMiddleware wraps a handler in the given middleware, such that the first middleware is the outermost.
+func applyMiddleware(handler InvocationHandler, middleware []Middleware) InvocationHandler {
+	for index := len(middleware) - 1; index >= 0; index-- {
+		handler = middleware[index](handler)
+	}
+
+	return handler
+}
@@ -0,0 +1,145 @@
+package switchboard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func recordingMiddleware(name string, calls *[]string) Middleware {
+	return func(next InvocationHandler) InvocationHandler {
+		return func(invocation *Invocation) error {
+			*calls = append(*calls, name+" before")
+			err := next(invocation)
+			*calls = append(*calls, name+" after")
+			return err
+		}
+	}
+}
+
+func TestSwitchboard_Use(t *testing.T) {
+	var calls []string
+
+	s := &Switchboard{}
+	s.Use(recordingMiddleware("first", &calls), recordingMiddleware("second", &calls))
+	_ = s.AddCommand(&Command{
+		Name:        "test",
+		Description: "Test command",
+		Handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {
+			calls = append(calls, "handler")
+		},
+		Middleware: []Middleware{recordingMiddleware("command", &calls)},
+	})
+	_ = s.AddComponentHandler("button", func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {
+		calls = append(calls, "component")
+	})
+
+	s.HandleInteractionCreate(nil, &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{Name: "test"},
+		},
+	})
+	s.HandleInteractionCreate(nil, &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionMessageComponent,
+			Data: discordgo.MessageComponentInteractionData{CustomID: "button"},
+		},
+	})
+
+	if diff := deep.Equal(
+		calls,
+		[]string{
+			"first before",
+			"second before",
+			"command before",
+			"handler",
+			"command after",
+			"second after",
+			"first after",
+			"first before",
+			"second before",
+			"component",
+			"second after",
+			"first after",
+		},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestSwitchboard_Use_WithShortCircuit(t *testing.T) {
+	deniedErr := errors.New("permission denied")
+	var receivedErr error
+	var observedCommand *Command
+
+	s := &Switchboard{
+		ErrorHandler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, err error) {
+			receivedErr = err
+		},
+	}
+	s.Use(func(next InvocationHandler) InvocationHandler {
+		return func(invocation *Invocation) error {
+			observedCommand = invocation.Command
+			return deniedErr
+		}
+	})
+	_ = s.AddCommand(&Command{
+		Name:        "test",
+		Description: "Test command",
+		Handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {
+			t.Error("handler function unexpectedly called")
+		},
+	})
+
+	s.HandleInteractionCreate(nil, &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{Name: "test"},
+		},
+	})
+
+	if !errors.Is(receivedErr, deniedErr) {
+		t.Errorf("got unexpected error: %v", receivedErr)
+	}
+	if observedCommand == nil || observedCommand.Name != "test" {
+		t.Errorf("middleware did not receive expected command: %#v", observedCommand)
+	}
+}
+
+func TestSwitchboard_Use_WithUnhandledInteraction(t *testing.T) {
+	var observedErrs []error
+
+	s := &Switchboard{}
+	s.Use(func(next InvocationHandler) InvocationHandler {
+		return func(invocation *Invocation) error {
+			err := next(invocation)
+			observedErrs = append(observedErrs, err)
+			return err
+		}
+	})
+
+	s.HandleInteractionCreate(nil, &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{Name: "test"},
+		},
+	})
+	s.HandleInteractionCreate(nil, &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionPing,
+		},
+	})
+
+	if len(observedErrs) != 2 {
+		t.Fatalf("expected middleware to observe 2 errors, got %d", len(observedErrs))
+	}
+	if !errors.Is(observedErrs[0], ErrUnknownCommand) {
+		t.Errorf("got unexpected error for unknown command: %v", observedErrs[0])
+	}
+	if !errors.Is(observedErrs[1], ErrUnsupportedInteractionType) {
+		t.Errorf("got unexpected error for unsupported interaction: %v", observedErrs[1])
+	}
+}
@@ -0,0 +1,274 @@
+package switchboard
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord allows at most five text inputs in a single modal.
+const maxModalTextInputs = 5
+
+// Discord limits text input labels to 45 characters, and their values to 4000 characters.
+const (
+	maxTextInputLabelLength = 45
+	maxTextInputLength      = 4000
+)
+
+var textInputStyles = map[string]discordgo.TextInputStyle{
+	"":          discordgo.TextInputShort,
+	"short":     discordgo.TextInputShort,
+	"paragraph": discordgo.TextInputParagraph,
+}
+
+// isTextInputRequired determines whether a modal field must be filled in. The required tag takes precedence,
+// otherwise the same rules as slash command options apply.
+func isTextInputRequired(field reflect.StructField) (bool, error) {
+	if requiredTag, hasRequired := field.Tag.Lookup("required"); hasRequired {
+		required, err := strconv.ParseBool(requiredTag)
+		if err != nil {
+			return false, fmt.Errorf("error parsing required tag: %w", err)
+		}
+		return required, nil
+	}
+
+	_, hasDefault := field.Tag.Lookup("default")
+
+	return !(hasDefault || field.Type.Kind() == reflect.Ptr), nil
+}
+
+func getTextInput(field reflect.StructField, value reflect.Value, naming NamingStrategy) (*discordgo.TextInput, error) {
+	style, validStyle := textInputStyles[field.Tag.Get("style")]
+	if !validStyle {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTextInputStyle, field.Tag.Get("style"))
+	}
+
+	required, err := isTextInputRequired(field)
+	if err != nil {
+		return nil, err
+	}
+
+	label, hasLabel := field.Tag.Lookup("label")
+	if !hasLabel {
+		label = field.Name
+	}
+
+	if labelLength := utf8.RuneCountInString(label); labelLength < 1 || labelLength > maxTextInputLabelLength {
+		return nil, fmt.Errorf("%w: must be 1-45 characters, got %d", ErrInvalidTextInputLabel, labelLength)
+	}
+
+	minLength, maxLength, err := parseLengthConstraints(field, maxTextInputLength)
+	if err != nil {
+		return nil, err
+	}
+
+	textInput := &discordgo.TextInput{
+		CustomID:    getFieldName(field, naming),
+		Label:       label,
+		Style:       style,
+		Placeholder: field.Tag.Get("placeholder"),
+		Required:    required,
+	}
+
+	if minLength != nil {
+		textInput.MinLength = *minLength
+	}
+
+	if maxLength != nil {
+		textInput.MaxLength = *maxLength
+	}
+
+	if value.Kind() == reflect.Ptr {
+		if !value.IsNil() {
+			textInput.Value = fmt.Sprint(value.Elem().Interface())
+		}
+	} else if !value.IsZero() {
+		textInput.Value = fmt.Sprint(value.Interface())
+	}
+
+	return textInput, nil
+}
+
+// NewModalResponse builds a modal interaction response with a text input for each field of the given struct,
+// suitable for decoding with a handler registered through AddModalHandler. Field values which are already set are
+// used to pre-fill the inputs, and fields tagged with `modal:"-"` (such as those populated from custom ID parameters)
+// are skipped. Custom IDs are derived from field names using DefaultNamingStrategy, so for Switchboards with their own
+// NamingStrategy, the Switchboard's NewModalResponse should be used instead.
+func NewModalResponse(customID string, title string, fields any) (*discordgo.InteractionResponse, error) {
+	return newModalResponse(customID, title, fields, DefaultNamingStrategy)
+}
+
+// NewModalResponse builds a modal interaction response as with the package-level NewModalResponse, deriving custom IDs
+// from field names using the Switchboard's NamingStrategy so that they match its modal handlers.
+func (s *Switchboard) NewModalResponse(
+	customID string,
+	title string,
+	fields any,
+) (*discordgo.InteractionResponse, error) {
+	return newModalResponse(customID, title, fields, s.getNamingStrategy())
+}
+
+func newModalResponse(
+	customID string,
+	title string,
+	fields any,
+	naming NamingStrategy,
+) (*discordgo.InteractionResponse, error) {
+	fieldsValue := reflect.ValueOf(fields)
+	if fieldsValue.Kind() == reflect.Ptr {
+		fieldsValue = fieldsValue.Elem()
+	}
+	if fieldsValue.Kind() != reflect.Struct {
+		return nil, ErrModalFieldsNotStruct
+	}
+
+	err := validateStringArgs(fieldsValue.Type(), naming)
+	if err != nil {
+		return nil, err
+	}
+
+	//goland:noinspection GoPreferNilSlice
+	components := []discordgo.MessageComponent{}
+
+	for index := 0; index < fieldsValue.NumField(); index++ {
+		field := fieldsValue.Type().Field(index)
+		if field.Tag.Get("modal") == "-" {
+			continue
+		}
+
+		textInput, err := getTextInput(field, fieldsValue.Field(index), naming)
+		if err != nil {
+			return nil, fmt.Errorf("error generating text input for struct field %s: %w", field.Name, err)
+		}
+
+		components = append(components, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{*textInput},
+		})
+	}
+
+	if len(components) > maxModalTextInputs {
+		return nil, ErrTooManyModalTextInputs
+	}
+
+	return &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID:   customID,
+			Title:      title,
+			Components: components,
+		},
+	}, nil
+}
+
+func validateModalHandler(handler any, naming NamingStrategy) error {
+	err := validateSlashCommand(handler)
+	if err != nil {
+		return err
+	}
+
+	argsType := getArgsType(handler)
+
+	err = validateStringArgs(argsType, naming)
+	if err != nil {
+		return err
+	}
+
+	// Ensure the text inputs for the handler's fields are valid, so that problems surface here rather than when the
+	// modal is shown
+	textInputCount := 0
+	for index := 0; index < argsType.NumField(); index++ {
+		field := argsType.Field(index)
+		if field.Tag.Get("modal") == "-" {
+			continue
+		}
+		textInputCount++
+
+		_, err = getTextInput(field, reflect.Zero(field.Type), naming)
+		if err != nil {
+			return fmt.Errorf("invalid text input for struct field %s: %w", field.Name, err)
+		}
+	}
+
+	if textInputCount > maxModalTextInputs {
+		return ErrTooManyModalTextInputs
+	}
+
+	return nil
+}
+
+// getTextInputValues collects the values of every non-empty text input in a submitted modal, keyed by custom ID.
+func getTextInputValues(components []discordgo.MessageComponent) map[string]string {
+	values := map[string]string{}
+
+	for _, component := range components {
+		switch typedComponent := component.(type) {
+		case *discordgo.ActionsRow:
+			for key, value := range getTextInputValues(typedComponent.Components) {
+				values[key] = value
+			}
+		case discordgo.ActionsRow:
+			for key, value := range getTextInputValues(typedComponent.Components) {
+				values[key] = value
+			}
+		case *discordgo.TextInput:
+			if typedComponent.Value != "" {
+				values[typedComponent.CustomID] = typedComponent.Value
+			}
+		case discordgo.TextInput:
+			if typedComponent.Value != "" {
+				values[typedComponent.CustomID] = typedComponent.Value
+			}
+		}
+	}
+
+	return values
+}
+
+// AddModalHandler registers a handler for modal submissions whose custom ID matches the given pattern. The handler's
+// args struct is populated from the submitted text inputs by custom ID, as well as from any parameters captured by
+// the pattern.
+func (s *Switchboard) AddModalHandler(pattern string, handler any) error {
+	compiled, err := compileCustomIDPattern(pattern)
+	if err != nil {
+		return err
+	}
+
+	naming := s.getNamingStrategy()
+
+	err = validateModalHandler(handler, naming)
+	if err != nil {
+		return fmt.Errorf("invalid handler: %w", err)
+	}
+
+	s.modalRoutes = append(s.modalRoutes, &customIDRoute{pattern: compiled, handler: handler, naming: naming})
+
+	return nil
+}
+
+func (s *Switchboard) handleInteractionModalSubmit(invocation *Invocation) error {
+	session, interaction := invocation.Session, invocation.Interaction
+	data := interaction.ModalSubmitData()
+
+	for _, route := range s.modalRoutes {
+		values, matched := route.pattern.match(data.CustomID)
+		if !matched {
+			continue
+		}
+
+		for key, value := range getTextInputValues(data.Components) {
+			values[key] = value
+		}
+
+		args, err := decodeStringValues(values, getArgsType(route.handler), route.naming)
+		if err != nil {
+			return fmt.Errorf("error decoding modal %s: %w", data.CustomID, err)
+		}
+
+		return callHandler(invocation.Context, route.handler, session, interaction, args)
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnknownModal, data.CustomID)
+}
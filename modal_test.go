@@ -0,0 +1,304 @@
+package switchboard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func newModalSubmitInteraction(customID string, inputs map[string]string) *discordgo.InteractionCreate {
+	var components []discordgo.MessageComponent
+
+	for inputID, value := range inputs {
+		components = append(components, &discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				&discordgo.TextInput{CustomID: inputID, Value: value},
+			},
+		})
+	}
+
+	return &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionModalSubmit,
+			Data: discordgo.ModalSubmitInteractionData{
+				CustomID:   customID,
+				Components: components,
+			},
+		},
+	}
+}
+
+type bugReport struct {
+	Message  string
+	Title    string
+	Steps    *string
+	Severity int `default:"3"`
+}
+
+func TestSwitchboard_handleInteractionModalSubmit(t *testing.T) {
+	s := &Switchboard{}
+	called := false
+
+	err := s.AddModalHandler("bug-report:{message}", func(
+		_ *discordgo.Session,
+		_ *discordgo.InteractionCreate,
+		args bugReport,
+	) {
+		called = true
+
+		if diff := deep.Equal(args, bugReport{Message: "123", Title: "Broken", Severity: 3}); diff != nil {
+			t.Error(diff)
+		}
+	})
+	if err != nil {
+		t.Errorf("got unexpected error adding modal handler: %s", err)
+	}
+
+	err = s.handleInteractionModalSubmit(&Invocation{
+		Interaction: newModalSubmitInteraction("bug-report:123", map[string]string{"title": "Broken", "steps": ""}),
+	})
+	if err != nil {
+		t.Errorf("got unexpected error handling modal: %s", err)
+	}
+
+	if !called {
+		t.Error("handler function not called")
+	}
+}
+
+func TestSwitchboard_handleInteractionModalSubmit_WithMissingRequiredInput(t *testing.T) {
+	s := &Switchboard{}
+
+	_ = s.AddModalHandler("bug-report:{message}", func(
+		_ *discordgo.Session,
+		_ *discordgo.InteractionCreate,
+		_ bugReport,
+	) {
+		t.Error("handler function unexpectedly called")
+	})
+
+	err := s.handleInteractionModalSubmit(&Invocation{
+		Interaction: newModalSubmitInteraction("bug-report:123", map[string]string{"steps": "Click the button"}),
+	})
+
+	if err == nil {
+		t.Error("did not get expected error")
+	}
+	if !errors.Is(err, ErrMissingValue) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
+
+func TestSwitchboard_AddModalHandler_WithInvalidFieldType(t *testing.T) {
+	s := &Switchboard{}
+
+	err := s.AddModalHandler("bug-report", func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct {
+		User discordgo.User
+	}) {
+	})
+
+	if err == nil {
+		t.Error("did not get expected error")
+	}
+	if !errors.Is(err, ErrInvalidArgumentType) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
+
+func TestNewModalResponse(t *testing.T) {
+	type reportFields struct {
+		Message  string  `modal:"-"`
+		Title    string  `label:"Title" placeholder:"Short summary" maxLength:"100"`
+		Steps    *string `label:"Steps to reproduce" style:"paragraph" minLength:"10"`
+		Severity int     `label:"Severity" default:"3"`
+		Notes    string  `required:"false"`
+	}
+
+	response, err := NewModalResponse("bug-report:123", "Report a bug", reportFields{Severity: 5})
+	if err != nil {
+		t.Errorf("got unexpected error: %s", err)
+	}
+
+	if diff := deep.Equal(
+		response,
+		&discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseModal,
+			Data: &discordgo.InteractionResponseData{
+				CustomID: "bug-report:123",
+				Title:    "Report a bug",
+				Components: []discordgo.MessageComponent{
+					discordgo.ActionsRow{Components: []discordgo.MessageComponent{discordgo.TextInput{
+						CustomID:    "title",
+						Label:       "Title",
+						Style:       discordgo.TextInputShort,
+						Placeholder: "Short summary",
+						Required:    true,
+						MaxLength:   100,
+					}}},
+					discordgo.ActionsRow{Components: []discordgo.MessageComponent{discordgo.TextInput{
+						CustomID:  "steps",
+						Label:     "Steps to reproduce",
+						Style:     discordgo.TextInputParagraph,
+						MinLength: 10,
+					}}},
+					discordgo.ActionsRow{Components: []discordgo.MessageComponent{discordgo.TextInput{
+						CustomID: "severity",
+						Label:    "Severity",
+						Style:    discordgo.TextInputShort,
+						Value:    "5",
+					}}},
+					discordgo.ActionsRow{Components: []discordgo.MessageComponent{discordgo.TextInput{
+						CustomID: "notes",
+						Label:    "Notes",
+						Style:    discordgo.TextInputShort,
+					}}},
+				},
+			},
+		},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestNewModalResponse_WithInvalidStyle(t *testing.T) {
+	_, err := NewModalResponse("bug-report", "Report a bug", struct {
+		Title string `style:"long"`
+	}{})
+
+	if err == nil {
+		t.Error("did not get expected error")
+	}
+	if !errors.Is(err, ErrInvalidTextInputStyle) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
+
+func TestNewModalResponse_WithNonStruct(t *testing.T) {
+	_, err := NewModalResponse("bug-report", "Report a bug", "fields")
+
+	if err == nil {
+		t.Error("did not get expected error")
+	}
+	if !errors.Is(err, ErrModalFieldsNotStruct) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
+
+func TestNewModalResponse_WithInvalidLengthConstraints(t *testing.T) {
+	for _, fields := range []any{
+		struct {
+			Title string `maxLength:"4001"`
+		}{},
+		struct {
+			Title string `minLength:"10" maxLength:"5"`
+		}{},
+		struct {
+			Title string `maxLength:"0"`
+		}{},
+	} {
+		_, err := NewModalResponse("bug-report", "Report a bug", fields)
+		if !errors.Is(err, ErrInvalidConstraint) {
+			t.Errorf("got unexpected error for %T: %v", fields, err)
+		}
+	}
+}
+
+func TestNewModalResponse_WithInvalidLabel(t *testing.T) {
+	_, err := NewModalResponse("bug-report", "Report a bug", struct {
+		Title string `label:"A label which is far too long to be shown in a modal"`
+	}{})
+
+	if !errors.Is(err, ErrInvalidTextInputLabel) {
+		t.Errorf("got unexpected error: %v", err)
+	}
+}
+
+func TestSwitchboard_AddModalHandler_WithInvalidTextInput(t *testing.T) {
+	s := &Switchboard{}
+
+	err := s.AddModalHandler("bug-report", func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct {
+		Title string `minLength:"100" maxLength:"10"`
+	}) {
+	})
+
+	if !errors.Is(err, ErrInvalidConstraint) {
+		t.Errorf("got unexpected error: %v", err)
+	}
+}
+
+func TestSwitchboard_AddModalHandler_WithTooManyTextInputs(t *testing.T) {
+	s := &Switchboard{}
+
+	err := s.AddModalHandler("survey:{id}", func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct {
+		ID string `modal:"-"`
+		A  string
+		B  string
+		C  string
+		D  string
+		E  string
+	}) {
+	})
+	if err != nil {
+		t.Errorf("got unexpected error adding handler with skipped field: %s", err)
+	}
+
+	err = s.AddModalHandler("survey", func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct {
+		A string
+		B string
+		C string
+		D string
+		E string
+		F string
+	}) {
+	})
+
+	if !errors.Is(err, ErrTooManyModalTextInputs) {
+		t.Errorf("got unexpected error: %v", err)
+	}
+}
+
+func TestSwitchboard_NewModalResponse_WithNamingStrategy(t *testing.T) {
+	type bugTitle struct {
+		BugTitle string `label:"Title"`
+	}
+
+	s := &Switchboard{NamingStrategy: SnakeCaseNaming}
+	called := false
+
+	err := s.AddModalHandler("bug-report", func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args bugTitle) {
+		called = true
+
+		if diff := deep.Equal(args, bugTitle{BugTitle: "Broken"}); diff != nil {
+			t.Error(diff)
+		}
+	})
+	if err != nil {
+		t.Fatalf("got unexpected error adding modal handler: %s", err)
+	}
+
+	response, err := s.NewModalResponse("bug-report", "Report a bug", bugTitle{})
+	if err != nil {
+		t.Fatalf("got unexpected error building modal: %s", err)
+	}
+
+	// Submit the modal using the custom IDs of the text inputs in the response
+	inputs := map[string]string{}
+	for _, row := range response.Data.Components {
+		for _, component := range row.(discordgo.ActionsRow).Components {
+			inputs[component.(discordgo.TextInput).CustomID] = "Broken"
+		}
+	}
+
+	err = s.handleInteractionModalSubmit(&Invocation{
+		Interaction: newModalSubmitInteraction("bug-report", inputs),
+	})
+	if err != nil {
+		t.Errorf("got unexpected error handling modal: %s", err)
+	}
+
+	if !called {
+		t.Error("handler function not called")
+	}
+}
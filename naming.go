@@ -0,0 +1,109 @@
+package switchboard
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy converts the name of an args struct field into the name of its option, used for fields without a
+// name tag.
+type NamingStrategy func(fieldName string) string
+
+// DefaultNamingStrategy is the NamingStrategy used by Switchboards without a NamingStrategy of their own, and by
+// NewModalResponse. Switchboards capture their strategy when commands and handlers are added, so changing it has no
+// effect on those already added.
+var DefaultNamingStrategy NamingStrategy = LowerCaseNaming
+
+type namingStrategyKey struct{}
+
+// getContextNamingStrategy returns the NamingStrategy of the Switchboard handling an interaction, or
+// DefaultNamingStrategy if the context is not from one.
+func getContextNamingStrategy(ctx context.Context) NamingStrategy {
+	if naming, ok := ctx.Value(namingStrategyKey{}).(NamingStrategy); ok {
+		return naming
+	}
+
+	return DefaultNamingStrategy
+}
+
+var optionNameRegexp = regexp.MustCompile(`^[-_\p{L}\p{N}]{1,32}$`)
+
+// LowerCaseNaming lower-cases field names, so that `UserID` becomes `userid`.
+func LowerCaseNaming(fieldName string) string {
+	return strings.ToLower(fieldName)
+}
+
+// SnakeCaseNaming converts CamelCase field names to snake_case, so that `UserID` becomes `user_id`.
+func SnakeCaseNaming(fieldName string) string {
+	return splitCamelCase(fieldName, '_')
+}
+
+// KebabCaseNaming converts CamelCase field names to kebab-case, so that `UserID` becomes `user-id`.
+func KebabCaseNaming(fieldName string) string {
+	return splitCamelCase(fieldName, '-')
+}
+
+func splitCamelCase(name string, separator rune) string {
+	runes := []rune(name)
+	builder := strings.Builder{}
+
+	for index, char := range runes {
+		if index > 0 && unicode.IsUpper(char) {
+			previous := runes[index-1]
+			nextIsLower := index+1 < len(runes) && unicode.IsLower(runes[index+1])
+
+			// Split before the start of each word, treating runs of capitals (such as `ID` or `HTTP`) as a single word
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				builder.WriteRune(separator)
+			}
+		}
+
+		builder.WriteRune(unicode.ToLower(char))
+	}
+
+	return builder.String()
+}
+
+// getFieldName returns the name used to refer to an args struct field, either from its name tag or derived from the
+// field's name using the given NamingStrategy.
+func getFieldName(field reflect.StructField, naming NamingStrategy) string {
+	if name, hasName := field.Tag.Lookup("name"); hasName {
+		return name
+	}
+
+	return naming(field.Name)
+}
+
+func validateOptionName(name string) error {
+	if !optionNameRegexp.MatchString(name) {
+		return fmt.Errorf("%w: %q must be 1-32 letters, numbers, dashes or underscores", ErrInvalidOptionName, name)
+	}
+
+	if strings.ToLower(name) != name {
+		return fmt.Errorf("%w: %q must be lower case", ErrInvalidOptionName, name)
+	}
+
+	return nil
+}
+
+// validateFieldNames ensures that no two fields of an args struct share the same name once their naming has been
+// applied.
+func validateFieldNames(argsType reflect.Type, naming NamingStrategy) error {
+	fields := map[string]string{}
+
+	for index := 0; index < argsType.NumField(); index++ {
+		field := argsType.Field(index)
+		name := getFieldName(field, naming)
+
+		if existing, isDuplicate := fields[name]; isDuplicate {
+			return fmt.Errorf("%w: fields %s and %s are both named %s", ErrDuplicateOptionName, existing, field.Name, name)
+		}
+		fields[name] = field.Name
+	}
+
+	return nil
+}
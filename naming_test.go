@@ -0,0 +1,205 @@
+package switchboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func TestSnakeCaseNaming(t *testing.T) {
+	tests := map[string]string{
+		"User":       "user",
+		"UserID":     "user_id",
+		"TargetUser": "target_user",
+		"HTTPServer": "http_server",
+		"Item2Count": "item2_count",
+		"already_ok": "already_ok",
+	}
+
+	for input, expected := range tests {
+		if actual := SnakeCaseNaming(input); actual != expected {
+			t.Errorf("expected %s to become %s, got %s", input, expected, actual)
+		}
+	}
+}
+
+func TestKebabCaseNaming(t *testing.T) {
+	if actual := KebabCaseNaming("TargetUserID"); actual != "target-user-id" {
+		t.Errorf("got unexpected name: %s", actual)
+	}
+}
+
+func Test_getCommandOptions_WithNamingStrategy(t *testing.T) {
+	options, err := getCommandOptions(
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+			TargetUser string `description:"Target user"`
+			UserID     string `description:"User ID" name:"user-id"`
+		}) {
+		},
+		SnakeCaseNaming,
+	)
+
+	if err != nil {
+		t.Errorf("got unexpected error getting command options: %s", err)
+	}
+
+	if diff := deep.Equal(
+		options,
+		[]*discordgo.ApplicationCommandOption{
+			{
+				Name:        "target_user",
+				Required:    true,
+				Type:        discordgo.ApplicationCommandOptionString,
+				Description: "Target user",
+			},
+			{
+				Name:        "user-id",
+				Required:    true,
+				Type:        discordgo.ApplicationCommandOptionString,
+				Description: "User ID",
+			},
+		},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func Test_getCommandOptions_WithInvalidName(t *testing.T) {
+	for _, handler := range []any{
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+			Target string `description:"Target" name:"target user"`
+		}) {
+		},
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+			Target string `description:"Target" name:"Target"`
+		}) {
+		},
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+			Target string `description:"Target" name:"a_name_which_is_far_too_long_for_discord"`
+		}) {
+		},
+	} {
+		_, err := getCommandOptions(handler, LowerCaseNaming)
+
+		if err == nil {
+			t.Error("did not get expected error when getting command options")
+		}
+		if !errors.Is(err, ErrInvalidOptionName) {
+			t.Errorf("got unexpected error when getting command options: %s", err)
+		}
+	}
+}
+
+func Test_getCommandOptions_WithDuplicateNames(t *testing.T) {
+	_, err := getCommandOptions(
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+			UserID string `description:"User ID"`
+			Userid string `description:"Also user ID"`
+		}) {
+		},
+		LowerCaseNaming,
+	)
+
+	if err == nil {
+		t.Error("did not get expected error when getting command options")
+	}
+	if !errors.Is(err, ErrDuplicateOptionName) {
+		t.Errorf("got unexpected error when getting command options: %s", err)
+	}
+}
+
+func Test_invokeCommand_SlashCommand_WithNamedArgs(t *testing.T) {
+	interactionData := discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name:  "target_user",
+						Type:  discordgo.ApplicationCommandOptionString,
+						Value: "test",
+					},
+				},
+			},
+		},
+	}
+
+	called := false
+
+	type Args struct {
+		Target string `name:"target_user"`
+	}
+
+	err := invokeCommand(
+		context.Background(),
+		&Command{
+			Type: SlashCommand,
+		},
+		nil,
+		&interactionData,
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args Args) {
+			called = true
+
+			if diff := deep.Equal(args, Args{Target: "test"}); diff != nil {
+				t.Error(diff)
+			}
+		})
+
+	if err != nil {
+		t.Errorf("got unexpected error: %s", err)
+	}
+	if !called {
+		t.Error("handler function not called")
+	}
+}
+
+func TestSwitchboard_AddCommand_WithNamingStrategy(t *testing.T) {
+	s := &Switchboard{NamingStrategy: SnakeCaseNaming}
+
+	called := false
+
+	type Args struct {
+		TargetUser string `description:"Target user"`
+	}
+
+	err := s.AddCommand(&Command{
+		Name:        "test",
+		Description: "Test",
+		Handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args Args) {
+			called = true
+
+			if diff := deep.Equal(args, Args{TargetUser: "test"}); diff != nil {
+				t.Error(diff)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("got unexpected error adding command: %s", err)
+	}
+
+	// The strategy is captured when the command is added, so later changes have no effect
+	s.NamingStrategy = KebabCaseNaming
+
+	s.HandleInteractionCreate(nil, &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name: "test",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name:  "target_user",
+						Type:  discordgo.ApplicationCommandOptionString,
+						Value: "test",
+					},
+				},
+			},
+		},
+	})
+
+	if !called {
+		t.Error("handler function not called")
+	}
+}
@@ -0,0 +1,60 @@
+package switchboard
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// PanicError is the error passed to the Switchboard's ErrorHandler when handling an interaction panics.
+type PanicError struct {
+	// The value passed to panic.
+	Value any
+
+	// The stack trace of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic while handling interaction: %v\n%s", e.Value, e.Stack)
+}
+
+// Unwrap returns the value passed to panic if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// callRecovering calls handler with the given invocation, converting any panic into a PanicError.
+func callRecovering(handler InvocationHandler, invocation *Invocation) (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			err = &PanicError{Value: value, Stack: debug.Stack()}
+		}
+	}()
+
+	return handler(invocation)
+}
+
+// sendPanicMessage informs the user that handling their interaction failed, if a PanicMessage has been configured and
+// the interaction can still be responded to with a message.
+func (s *Switchboard) sendPanicMessage(r *responder, interaction *discordgo.InteractionCreate) error {
+	if s.PanicMessage == "" || interaction.Type == discordgo.InteractionApplicationCommandAutocomplete {
+		return nil
+	}
+
+	err := r.respond(&discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: s.PanicMessage,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil && !errors.Is(err, ErrAlreadyResponded) {
+		return fmt.Errorf("error sending panic message: %w", err)
+	}
+
+	return nil
+}
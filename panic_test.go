@@ -0,0 +1,142 @@
+package switchboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func TestSwitchboard_HandleInteractionCreate_WithPanic(t *testing.T) {
+	session, getRequests := newTestSession(t, nil)
+	var receivedErr error
+
+	s := &Switchboard{
+		ErrorHandler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, err error) {
+			receivedErr = err
+		},
+		PanicMessage: "Something went wrong.",
+	}
+	_ = s.AddCommand(&Command{
+		Name:        "test",
+		Description: "Test command",
+		Handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {
+			panic("handler failed")
+		},
+	})
+
+	s.HandleInteractionCreate(session, newTestCommandInteraction("test"))
+
+	var panicErr *PanicError
+	if !errors.As(receivedErr, &panicErr) {
+		t.Fatalf("got unexpected error: %v", receivedErr)
+	}
+	if panicErr.Value != "handler failed" {
+		t.Errorf("got unexpected panic value: %v", panicErr.Value)
+	}
+	if len(panicErr.Stack) == 0 {
+		t.Error("panic error has no stack trace")
+	}
+
+	requests := getRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+
+	if diff := deep.Equal(
+		requests[0].Body["data"],
+		map[string]any{
+			"tts":        false,
+			"content":    "Something went wrong.",
+			"components": nil,
+			"embeds":     nil,
+			"flags":      float64(discordgo.MessageFlagsEphemeral),
+		},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestSwitchboard_HandleInteractionCreate_WithPanicAfterResponding(t *testing.T) {
+	session, getRequests := newTestSession(t, nil)
+	receivedErrs := []error{}
+	panicValue := errors.New("handler failed")
+
+	s := &Switchboard{
+		ErrorHandler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, err error) {
+			receivedErrs = append(receivedErrs, err)
+		},
+		PanicMessage: "Something went wrong.",
+	}
+	_ = s.AddComponentHandler(
+		"button",
+		func(ctx *Context, _ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {
+			_ = ctx.Reply(&discordgo.InteractionResponseData{Content: "Hello world!"})
+			panic(panicValue)
+		},
+	)
+
+	s.HandleInteractionCreate(session, &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			ID:    "1",
+			AppID: "2",
+			Token: "token",
+			Type:  discordgo.InteractionMessageComponent,
+			Data:  discordgo.MessageComponentInteractionData{CustomID: "button"},
+		},
+	})
+
+	if len(receivedErrs) != 1 || !errors.Is(receivedErrs[0], panicValue) {
+		t.Errorf("got unexpected errors: %v", receivedErrs)
+	}
+
+	if len(getRequests()) != 1 {
+		t.Errorf("expected 1 request, got %d", len(getRequests()))
+	}
+}
+
+func TestSwitchboard_HandleInteractionCreate_WithPanicInMiddleware(t *testing.T) {
+	var receivedErr error
+
+	s := &Switchboard{
+		ErrorHandler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, err error) {
+			receivedErr = err
+		},
+	}
+	s.Use(func(next InvocationHandler) InvocationHandler {
+		return func(invocation *Invocation) error {
+			panic("middleware failed")
+		}
+	})
+	_ = s.AddCommand(&Command{
+		Name:        "test",
+		Description: "Test command",
+		Handler:     func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {},
+	})
+
+	s.HandleInteractionCreate(nil, newTestCommandInteraction("test"))
+
+	var panicErr *PanicError
+	if !errors.As(receivedErr, &panicErr) || panicErr.Value != "middleware failed" {
+		t.Errorf("got unexpected error: %v", receivedErr)
+	}
+}
+
+func Test_invokeCommand_SlashCommand_WithInvalidDefault(t *testing.T) {
+	err := invokeCommand(
+		context.Background(),
+		&Command{Type: SlashCommand},
+		&discordgo.Session{},
+		newTestCommandInteraction("test"),
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct {
+			Count int `description:"Count" default:"many"`
+		}) {
+			t.Error("handler function unexpectedly called")
+		},
+	)
+	if err == nil {
+		t.Error("expected error when populating invalid default value")
+	}
+}
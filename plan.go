@@ -0,0 +1,217 @@
+package switchboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// FieldChange describes a single field of a command which differs between Discord and the Switchboard.
+type FieldChange struct {
+	// The path to the field, such as "description" or "options.sides.choices". Options are identified by name.
+	Path string
+
+	// The value registered with Discord, or nil if it is unset.
+	Old any
+	// The value registered with the Switchboard, or nil if it is unset.
+	New any
+}
+
+// CommandUpdate describes a command which is registered with both Discord and the Switchboard, but differs.
+type CommandUpdate struct {
+	// The command registered with the Switchboard.
+	Command *discordgo.ApplicationCommand
+
+	// Every field which differs, in a deterministic order.
+	Changes []*FieldChange
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// getListNames returns the names of every item in a list of decoded options, or false if any item is unnamed.
+func getListNames(list []any) ([]any, bool) {
+	names := make([]any, 0, len(list))
+
+	for _, item := range list {
+		itemMap, isMap := item.(map[string]any)
+		if !isMap {
+			return nil, false
+		}
+
+		name, isString := itemMap["name"].(string)
+		if !isString {
+			return nil, false
+		}
+
+		names = append(names, name)
+	}
+
+	return names, true
+}
+
+// diffNamedLists compares two lists of named items, such as options, matching items by name rather than position.
+func diffNamedLists(path string, oldList, newList []any, oldNames, newNames []any) []*FieldChange {
+	var changes []*FieldChange
+
+	oldItems := map[any]any{}
+	for index, name := range oldNames {
+		oldItems[name] = oldList[index]
+	}
+
+	newItems := map[any]any{}
+	for index, name := range newNames {
+		newItems[name] = newList[index]
+	}
+
+	// Changes in the order of items which exist in both lists are reported against the list itself
+	var oldOrder, newOrder []any
+	for _, name := range oldNames {
+		if _, exists := newItems[name]; exists {
+			oldOrder = append(oldOrder, name)
+		}
+	}
+	for _, name := range newNames {
+		if _, exists := oldItems[name]; exists {
+			newOrder = append(newOrder, name)
+		}
+	}
+	if !reflect.DeepEqual(oldOrder, newOrder) {
+		changes = append(changes, &FieldChange{Path: path, Old: oldNames, New: newNames})
+	}
+
+	for index, name := range newNames {
+		changes = append(changes, diffValues(joinPath(path, name.(string)), oldItems[name], newList[index])...)
+	}
+
+	for index, name := range oldNames {
+		if _, exists := newItems[name]; !exists {
+			changes = append(changes, &FieldChange{Path: joinPath(path, name.(string)), Old: oldList[index]})
+		}
+	}
+
+	return changes
+}
+
+// diffValues compares two decoded JSON values, returning a change for every field which differs.
+func diffValues(path string, oldValue, newValue any) []*FieldChange {
+	oldMap, oldIsMap := oldValue.(map[string]any)
+	newMap, newIsMap := newValue.(map[string]any)
+	if oldIsMap && newIsMap {
+		keys := map[string]bool{}
+		for key := range oldMap {
+			keys[key] = true
+		}
+		for key := range newMap {
+			keys[key] = true
+		}
+
+		sortedKeys := make([]string, 0, len(keys))
+		for key := range keys {
+			sortedKeys = append(sortedKeys, key)
+		}
+		sort.Strings(sortedKeys)
+
+		var changes []*FieldChange
+		for _, key := range sortedKeys {
+			changes = append(changes, diffValues(joinPath(path, key), oldMap[key], newMap[key])...)
+		}
+
+		return changes
+	}
+
+	oldList, oldIsList := oldValue.([]any)
+	newList, newIsList := newValue.([]any)
+	if oldIsList && newIsList {
+		oldNames, oldIsNamed := getListNames(oldList)
+		newNames, newIsNamed := getListNames(newList)
+
+		// Choices are named, but have no nested fields worth comparing individually
+		if oldIsNamed && newIsNamed && !strings.HasSuffix(path, "choices") {
+			return diffNamedLists(path, oldList, newList, oldNames, newNames)
+		}
+	}
+
+	if reflect.DeepEqual(oldValue, newValue) {
+		return nil
+	}
+
+	return []*FieldChange{{Path: path, Old: oldValue, New: newValue}}
+}
+
+// formatValue renders a decoded JSON value for display.
+func formatValue(value any) string {
+	if value == nil {
+		return "(unset)"
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(data)
+}
+
+// formatCommandName renders the name of a command for display, distinguishing between types of commands.
+func formatCommandName(command *discordgo.ApplicationCommand) string {
+	switch getCommandKey(command).Type {
+	case discordgo.UserApplicationCommand:
+		return command.Name + " (user command)"
+	case discordgo.MessageApplicationCommand:
+		return command.Name + " (message command)"
+	default:
+		return "/" + command.Name
+	}
+}
+
+// String renders the report in a human-readable form, suitable for reviewing the changes a sync will make.
+func (r *SyncReport) String() string {
+	var builder strings.Builder
+	var added, updated, removed int
+
+	for _, scope := range r.Scopes {
+		if scope.GuildID == "" {
+			builder.WriteString("Global commands:\n")
+		} else {
+			fmt.Fprintf(&builder, "Guild %s:\n", scope.GuildID)
+		}
+
+		if !scope.HasChanges() {
+			builder.WriteString("  no changes\n")
+			continue
+		}
+
+		for _, command := range scope.Added {
+			fmt.Fprintf(&builder, "  + %s\n", formatCommandName(command))
+		}
+
+		for _, update := range scope.Updated {
+			fmt.Fprintf(&builder, "  ~ %s\n", formatCommandName(update.Command))
+			for _, change := range update.Changes {
+				fmt.Fprintf(&builder, "      %s: %s -> %s\n", change.Path, formatValue(change.Old), formatValue(change.New))
+			}
+		}
+
+		for _, command := range scope.Removed {
+			fmt.Fprintf(&builder, "  - %s\n", formatCommandName(command))
+		}
+
+		added += len(scope.Added)
+		updated += len(scope.Updated)
+		removed += len(scope.Removed)
+	}
+
+	fmt.Fprintf(&builder, "%d to add, %d to update, %d to remove.\n", added, updated, removed)
+
+	return builder.String()
+}
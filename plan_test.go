@@ -0,0 +1,79 @@
+package switchboard
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestSwitchboard_PlanSync(t *testing.T) {
+	s := newSyncTestSwitchboard(t)
+	session, getRequests := newTestSession(t, respondWithRemoteCommands)
+
+	report, err := s.PlanSync(session, "app")
+	if err != nil {
+		t.Fatalf("got unexpected error planning sync: %s", err)
+	}
+
+	for _, request := range getRequests() {
+		if request.Method != http.MethodGet {
+			t.Errorf("got unexpected request when planning sync: %s %s", request.Method, request.Path)
+		}
+	}
+
+	if !report.HasChanges() {
+		t.Error("expected report to have changes")
+	}
+
+	if diff := deep.Equal(
+		report.String(),
+		`Global commands:
+  no changes
+Guild 1:
+  ~ /echo
+      description: "An outdated description" -> "Echo some text"
+  - /removed
+0 to add, 1 to update, 1 to remove.
+`,
+	); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func Test_diffValues_WithOptions(t *testing.T) {
+	oldCommand := map[string]any{
+		"name": "roll",
+		"options": []any{
+			map[string]any{"name": "sides", "type": 4.0, "required": true},
+			map[string]any{"name": "count", "type": 4.0},
+			map[string]any{"name": "label", "type": 3.0},
+		},
+	}
+	newCommand := map[string]any{
+		"name": "roll",
+		"options": []any{
+			map[string]any{"name": "count", "type": 4.0, "min_value": 1.0},
+			map[string]any{
+				"name":    "sides",
+				"type":    4.0,
+				"choices": []any{map[string]any{"name": "6", "value": 6.0}},
+			},
+			map[string]any{"name": "modifier", "type": 4.0},
+		},
+	}
+
+	if diff := deep.Equal(
+		diffValues("", oldCommand, newCommand),
+		[]*FieldChange{
+			{Path: "options", Old: []any{"sides", "count", "label"}, New: []any{"count", "sides", "modifier"}},
+			{Path: "options.count.min_value", New: 1.0},
+			{Path: "options.sides.choices", New: []any{map[string]any{"name": "6", "value": 6.0}}},
+			{Path: "options.sides.required", Old: true},
+			{Path: "options.modifier", New: map[string]any{"name": "modifier", "type": 4.0}},
+			{Path: "options.label", Old: map[string]any{"name": "label", "type": 3.0}},
+		},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
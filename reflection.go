@@ -1,24 +1,24 @@
 package switchboard
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
-	"strings"
 
 	"github.com/bwmarrin/discordgo"
 )
 
 var argTypeMap = map[reflect.Type]discordgo.ApplicationCommandOptionType{
-	reflect.TypeOf(""):    discordgo.ApplicationCommandOptionString,
-	reflect.TypeOf(0):     discordgo.ApplicationCommandOptionInteger,
-	reflect.TypeOf(false): discordgo.ApplicationCommandOptionBoolean,
-	// TODO: Should this be a `User` or a `Member`?
-	reflect.TypeOf(discordgo.User{}):    discordgo.ApplicationCommandOptionUser,
-	reflect.TypeOf(discordgo.Channel{}): discordgo.ApplicationCommandOptionChannel,
-	reflect.TypeOf(discordgo.Role{}):    discordgo.ApplicationCommandOptionRole,
-	// TODO: What type should `MENTIONABLE` map to?
-	// ???: discordgo.ApplicationCommandOptionMentionable,
+	reflect.TypeOf(""):                            discordgo.ApplicationCommandOptionString,
+	reflect.TypeOf(0):                             discordgo.ApplicationCommandOptionInteger,
+	reflect.TypeOf(false):                         discordgo.ApplicationCommandOptionBoolean,
+	reflect.TypeOf(discordgo.User{}):              discordgo.ApplicationCommandOptionUser,
+	reflect.TypeOf(discordgo.Member{}):            discordgo.ApplicationCommandOptionUser,
+	reflect.TypeOf(discordgo.Channel{}):           discordgo.ApplicationCommandOptionChannel,
+	reflect.TypeOf(discordgo.Role{}):              discordgo.ApplicationCommandOptionRole,
+	reflect.TypeOf(Mentionable{}):                 discordgo.ApplicationCommandOptionMentionable,
 	reflect.TypeOf(0.0):                           discordgo.ApplicationCommandOptionNumber,
 	reflect.TypeOf(discordgo.MessageAttachment{}): discordgo.ApplicationCommandOptionAttachment,
 	reflect.TypeOf(uint(0)):                       discordgo.ApplicationCommandOptionInteger,
@@ -29,7 +29,7 @@ func getOptionType(argType reflect.Type) (discordgo.ApplicationCommandOptionType
 		return getOptionType(argType.Elem())
 	}
 
-	argOptionType, validType := argTypeMap[argType]
+	argOptionType, validType := argTypeMap[getBaseType(argType)]
 
 	if validType {
 		return argOptionType, nil
@@ -38,73 +38,241 @@ func getOptionType(argType reflect.Type) (discordgo.ApplicationCommandOptionType
 	}
 }
 
-func getCommandOptions(handler any) ([]*discordgo.ApplicationCommandOption, error) {
+func getCommandOption(arg reflect.StructField, naming NamingStrategy) (*discordgo.ApplicationCommandOption, error) {
+	_, hasDefault := arg.Tag.Lookup("default")
+	isPtr := arg.Type.Kind() == reflect.Ptr
+
+	optionType, err := getOptionType(arg.Type)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine type for struct field %s: %w", arg.Name, err)
+	}
+
+	err = validateOptionName(getFieldName(arg, naming))
+	if err != nil {
+		return nil, fmt.Errorf("invalid name for struct field %s: %w", arg.Name, err)
+	}
+
+	_, hasAutocomplete := arg.Tag.Lookup("autocomplete")
+	if hasAutocomplete &&
+		optionType != discordgo.ApplicationCommandOptionString &&
+		optionType != discordgo.ApplicationCommandOptionInteger &&
+		optionType != discordgo.ApplicationCommandOptionNumber {
+		return nil, fmt.Errorf(
+			"unable to enable autocomplete for struct field %s: %w",
+			arg.Name,
+			ErrAutocompleteUnsupportedType,
+		)
+	}
+
+	choices, err := getOptionChoices(arg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine choices for struct field %s: %w", arg.Name, err)
+	}
+
+	if hasAutocomplete && choices != nil {
+		return nil, fmt.Errorf("unable to enable autocomplete for struct field %s: %w", arg.Name, ErrChoicesWithAutocomplete)
+	}
+
+	description, hasDescription := arg.Tag.Lookup("description")
+	if !hasDescription {
+		return nil, fmt.Errorf("no description provided for argument %s", arg.Name)
+	}
+
+	err = validateDescription(description)
+	if err != nil {
+		return nil, fmt.Errorf("invalid description for struct field %s: %w", arg.Name, err)
+	}
+
+	if hasDefault {
+		// Pointer fields are left nil when their option is omitted, so their default is only checked against the type
+		// they point to
+		defaultField := arg
+		if isPtr {
+			defaultField.Type = arg.Type.Elem()
+		}
+
+		_, err = getDefaultValue(defaultField)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default value for struct field %s: %w", arg.Name, err)
+		}
+	}
+
+	channelTypes, err := getChannelTypes(arg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine channel types for struct field %s: %w", arg.Name, err)
+	}
+
+	if channelTypes != nil && optionType != discordgo.ApplicationCommandOptionChannel {
+		return nil, fmt.Errorf(
+			"unable to restrict channel types for struct field %s: %w",
+			arg.Name,
+			ErrChannelTypesUnsupportedType,
+		)
+	}
+
+	option := &discordgo.ApplicationCommandOption{
+		Name:         getFieldName(arg, naming),
+		Required:     !(hasDefault || isPtr),
+		Type:         optionType,
+		Description:  description,
+		Autocomplete: hasAutocomplete,
+		Choices:      choices,
+		ChannelTypes: channelTypes,
+	}
+
+	resolvedType := arg.Type
+	if resolvedType.Kind() == reflect.Ptr {
+		resolvedType = resolvedType.Elem()
+	}
+
+	if getBaseType(resolvedType) == reflect.TypeOf(uint(0)) && choices == nil {
+		minValue := 0.0
+		option.MinValue = &minValue
+	}
+
+	err = applyValueConstraints(arg, option)
+	if err != nil {
+		return nil, fmt.Errorf("invalid constraint for struct field %s: %w", arg.Name, err)
+	}
+
+	err = applyLengthConstraints(arg, option)
+	if err != nil {
+		return nil, fmt.Errorf("invalid constraint for struct field %s: %w", arg.Name, err)
+	}
+
+	return option, nil
+}
+
+func getCommandOptions(handler any, naming NamingStrategy) ([]*discordgo.ApplicationCommandOption, error) {
 	// Assumes validateHandler has been called before passing a handler to this function - will potentially panic otherwise
-	argsStructType := reflect.TypeOf(handler).In(2)
+	argsStructType := getArgsType(handler)
+
+	err := validateFieldNames(argsStructType, naming)
+	if err != nil {
+		return nil, err
+	}
 
 	//goland:noinspection GoPreferNilSlice
 	options := []*discordgo.ApplicationCommandOption{}
 
 	for index := 0; index < argsStructType.NumField(); index++ {
-		arg := argsStructType.Field(index)
-		_, hasDefault := arg.Tag.Lookup("default")
-		isPtr := arg.Type.Kind() == reflect.Ptr
-
-		optionType, err := getOptionType(arg.Type)
+		option, err := getCommandOption(argsStructType.Field(index), naming)
 		if err != nil {
-			return nil, fmt.Errorf("unable to determine type for struct field %s: %w", arg.Name, err)
+			return nil, err
 		}
 
-		description, hasDescription := arg.Tag.Lookup("description")
-		if !hasDescription {
-			return nil, fmt.Errorf("no description provided for argument %s", arg.Name)
-		}
+		options = append(options, option)
+	}
+
+	return options, nil
+}
+
+// hasMemberOption determines whether a slash command handler has any options which require guild membership data.
+func hasMemberOption(handler any) bool {
+	argsStructType := getArgsType(handler)
 
-		option := &discordgo.ApplicationCommandOption{
-			Name:        strings.ToLower(arg.Name),
-			Required:    !(hasDefault || isPtr),
-			Type:        optionType,
-			Description: description,
+	for index := 0; index < argsStructType.NumField(); index++ {
+		fieldType := argsStructType.Field(index).Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
 		}
 
-		resolvedType := arg.Type
-		if resolvedType.Kind() == reflect.Ptr {
-			resolvedType = resolvedType.Elem()
+		if fieldType == reflect.TypeOf(discordgo.Member{}) {
+			return true
 		}
+	}
+
+	return false
+}
+
+// getOptionField finds the field of a slash command handler's args struct which corresponds to the named option.
+func getOptionField(handler any, optionName string, naming NamingStrategy) (reflect.StructField, bool) {
+	argsStructType := getArgsType(handler)
 
-		if resolvedType == reflect.TypeOf(uint(0)) {
-			minValue := 0.0
-			option.MinValue = &minValue
+	for index := 0; index < argsStructType.NumField(); index++ {
+		field := argsStructType.Field(index)
+		if getFieldName(field, naming) == optionName {
+			return field, true
 		}
+	}
 
-		options = append(options, option)
+	return reflect.StructField{}, false
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// getParamOffset returns the number of leading context parameters, either a context.Context or a *Context, accepted by
+// a handler.
+func getParamOffset(handlerType reflect.Type) int {
+	if handlerType.NumIn() > 0 && (handlerType.In(0) == contextType || handlerType.In(0) == switchboardContextType) {
+		return 1
 	}
 
-	return options, nil
+	return 0
 }
 
-func validateSlashCommand(handler any) error {
+// getHandlerParam returns the type of a handler's parameter at the given index, not counting any leading context
+// parameter.
+func getHandlerParam(handlerType reflect.Type, index int) reflect.Type {
+	return handlerType.In(index + getParamOffset(handlerType))
+}
+
+// getHandlerParamCount returns the number of parameters a handler accepts, not counting any leading context parameter.
+func getHandlerParamCount(handlerType reflect.Type) int {
+	return handlerType.NumIn() - getParamOffset(handlerType)
+}
+
+// getArgsType returns the type of the args struct accepted by a slash command, component or modal handler.
+func getArgsType(handler any) reflect.Type {
+	return getHandlerParam(reflect.TypeOf(handler), 2)
+}
+
+// validateHandlerBase performs the checks common to every handler - that it is a function accepting one of the given
+// numbers of parameters, the first two of which are the session and interaction, and that it returns either nothing
+// or an error. Handlers may additionally accept a context.Context or *Context as a leading parameter, which is not
+// counted.
+func validateHandlerBase(handler any, paramCounts ...int) (reflect.Type, error) {
 	handlerType := reflect.TypeOf(handler)
 
-	if handlerType.Kind() != reflect.Func {
-		return ErrHandlerNotFunction
+	if handlerType == nil || handlerType.Kind() != reflect.Func {
+		return nil, ErrHandlerNotFunction
 	}
 
-	if handlerType.NumIn() != 3 {
-		return ErrHandlerInvalidParameterCount
+	validParamCount := false
+	for _, paramCount := range paramCounts {
+		if getHandlerParamCount(handlerType) == paramCount {
+			validParamCount = true
+			break
+		}
+	}
+	if !validParamCount {
+		return nil, ErrHandlerInvalidParameterCount
 	}
 
-	firstParam := handlerType.In(0)
+	firstParam := getHandlerParam(handlerType, 0)
 	if firstParam.Kind() != reflect.Ptr || firstParam.Elem() != reflect.TypeOf(discordgo.Session{}) {
-		return ErrHandlerInvalidFirstParameterType
+		return nil, ErrHandlerInvalidFirstParameterType
 	}
 
-	secondParam := handlerType.In(1)
+	secondParam := getHandlerParam(handlerType, 1)
 	if secondParam.Kind() != reflect.Ptr || secondParam.Elem() != reflect.TypeOf(discordgo.InteractionCreate{}) {
-		return ErrHandlerInvalidSecondParameterType
+		return nil, ErrHandlerInvalidSecondParameterType
+	}
+
+	if handlerType.NumOut() > 1 || (handlerType.NumOut() == 1 && handlerType.Out(0) != errorType) {
+		return nil, ErrHandlerInvalidReturnType
 	}
 
-	if handlerType.In(2).Kind() != reflect.Struct {
+	return handlerType, nil
+}
+
+func validateSlashCommand(handler any) error {
+	handlerType, err := validateHandlerBase(handler, 3)
+	if err != nil {
+		return err
+	}
+
+	if getHandlerParam(handlerType, 2).Kind() != reflect.Struct {
 		return ErrHandlerInvalidThirdParameterType
 	}
 
@@ -112,29 +280,37 @@ func validateSlashCommand(handler any) error {
 }
 
 func validateMessageCommand(handler any) error {
-	handlerType := reflect.TypeOf(handler)
-
-	if handlerType.Kind() != reflect.Func {
-		return ErrHandlerNotFunction
+	handlerType, err := validateHandlerBase(handler, 3)
+	if err != nil {
+		return err
 	}
 
-	if handlerType.NumIn() != 3 {
-		return ErrHandlerInvalidParameterCount
+	thirdParam := getHandlerParam(handlerType, 2)
+	if thirdParam.Kind() != reflect.Ptr || thirdParam.Elem() != reflect.TypeOf(discordgo.Message{}) {
+		return ErrMessageHandlerInvalidThirdParameterType
 	}
 
-	firstParam := handlerType.In(0)
-	if firstParam.Kind() != reflect.Ptr || firstParam.Elem() != reflect.TypeOf(discordgo.Session{}) {
-		return ErrHandlerInvalidFirstParameterType
+	return nil
+}
+
+// validateUserCommand checks a user command handler, which receives the targeted user and optionally, as a fourth
+// parameter, their guild member (nil when invoked outside a guild).
+func validateUserCommand(handler any) error {
+	handlerType, err := validateHandlerBase(handler, 3, 4)
+	if err != nil {
+		return err
 	}
 
-	secondParam := handlerType.In(1)
-	if secondParam.Kind() != reflect.Ptr || secondParam.Elem() != reflect.TypeOf(discordgo.InteractionCreate{}) {
-		return ErrHandlerInvalidSecondParameterType
+	thirdParam := getHandlerParam(handlerType, 2)
+	if thirdParam.Kind() != reflect.Ptr || thirdParam.Elem() != reflect.TypeOf(discordgo.User{}) {
+		return ErrUserHandlerInvalidThirdParameterType
 	}
 
-	thirdParam := handlerType.In(2)
-	if thirdParam.Kind() != reflect.Ptr || thirdParam.Elem() != reflect.TypeOf(discordgo.Message{}) {
-		return ErrMessageHandlerInvalidThirdParameterType
+	if getHandlerParamCount(handlerType) == 4 {
+		fourthParam := getHandlerParam(handlerType, 3)
+		if fourthParam.Kind() != reflect.Ptr || fourthParam.Elem() != reflect.TypeOf(discordgo.Member{}) {
+			return ErrUserHandlerInvalidFourthParameterType
+		}
 	}
 
 	return nil
@@ -143,92 +319,343 @@ func validateMessageCommand(handler any) error {
 var validationFuncs = map[CommandType]func(any) error{
 	SlashCommand:   validateSlashCommand,
 	MessageCommand: validateMessageCommand,
+	UserCommand:    validateUserCommand,
 }
 
 func validateHandler(commandType CommandType, handler any) error {
 	return validationFuncs[commandType](handler)
 }
 
-func getDefaultValue(field reflect.StructField) (reflect.Value, error) {
-	defaultVal := field.Tag.Get("default")
+var stringArgTypes = map[reflect.Type]bool{
+	reflect.TypeOf(""):      true,
+	reflect.TypeOf(0):       true,
+	reflect.TypeOf(uint(0)): true,
+	reflect.TypeOf(false):   true,
+	reflect.TypeOf(0.0):     true,
+}
+
+// parseStringValue converts a raw string, such as a default value or a custom ID parameter, into the given type.
+func parseStringValue(valueType reflect.Type, raw string) (reflect.Value, error) {
+	baseType := getBaseType(valueType)
+	if baseType != valueType {
+		value, err := parseStringValue(baseType, raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return value.Convert(valueType), nil
+	}
 
-	switch field.Type {
+	switch valueType {
 	case reflect.TypeOf(""):
-		return reflect.ValueOf(defaultVal), nil
+		return reflect.ValueOf(raw), nil
 	case reflect.TypeOf(0):
-		intVal, err := strconv.Atoi(defaultVal)
+		intVal, err := strconv.Atoi(raw)
 		if err != nil {
-			return reflect.Value{}, fmt.Errorf("error parsing default value: %w", err)
+			return reflect.Value{}, err
 		}
 		return reflect.ValueOf(intVal), nil
 	case reflect.TypeOf(uint(0)):
-		uintVal, err := strconv.ParseUint(defaultVal, 10, 64)
+		uintVal, err := strconv.ParseUint(raw, 10, 64)
 		if err != nil {
-			return reflect.Value{}, fmt.Errorf("error parsing default value: %w", err)
+			return reflect.Value{}, err
 		}
 		return reflect.ValueOf(uint(uintVal)), nil
 	case reflect.TypeOf(false):
-		boolVal, err := strconv.ParseBool(defaultVal)
+		boolVal, err := strconv.ParseBool(raw)
 		if err != nil {
-			return reflect.Value{}, fmt.Errorf("error parsing default value: %w", err)
+			return reflect.Value{}, err
 		}
 		return reflect.ValueOf(boolVal), nil
 	case reflect.TypeOf(0.0):
-		floatVal, err := strconv.ParseFloat(defaultVal, 64)
+		floatVal, err := strconv.ParseFloat(raw, 64)
 		if err != nil {
-			return reflect.Value{}, fmt.Errorf("error parsing default value: %w", err)
+			return reflect.Value{}, err
 		}
 		return reflect.ValueOf(floatVal), nil
 	default:
+		return reflect.Value{}, ErrInvalidArgumentType
+	}
+}
+
+func getDefaultValue(field reflect.StructField) (reflect.Value, error) {
+	value, err := parseStringValue(field.Type, field.Tag.Get("default"))
+	if errors.Is(err, ErrInvalidArgumentType) {
 		return reflect.Value{}, ErrUnsupportedDefaultArgType
 	}
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("error parsing default value: %w", err)
+	}
+
+	return value, nil
 }
 
-func invokeSlashCommand(session *discordgo.Session, interaction *discordgo.InteractionCreate, handler any) {
+// decodeStringValues builds an instance of argsType from a map of raw string values keyed by field name, following
+// the same optionality rules as slash command options - pointer fields are left nil when no value is present, and
+// other fields fall back to their default tag or are left as their zero value if tagged with `required:"false"`.
+func decodeStringValues(
+	values map[string]string,
+	argsType reflect.Type,
+	naming NamingStrategy,
+) (reflect.Value, error) {
+	argsValue := reflect.New(argsType).Elem()
+
+	for index := 0; index < argsType.NumField(); index++ {
+		field := argsValue.Field(index)
+		fieldType := argsType.Field(index)
+		isPtr := fieldType.Type.Kind() == reflect.Ptr
+
+		raw, provided := values[getFieldName(fieldType, naming)]
+		if !provided {
+			if isPtr {
+				continue
+			}
+
+			if _, hasDefault := fieldType.Tag.Lookup("default"); !hasDefault {
+				// Fields explicitly marked as not required are left as their zero value
+				if fieldType.Tag.Get("required") == "false" {
+					continue
+				}
+
+				return reflect.Value{}, fmt.Errorf("field %s: %w", fieldType.Name, ErrMissingValue)
+			}
+
+			value, err := getDefaultValue(fieldType)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("error populating default value for field %s: %w", fieldType.Name, err)
+			}
+
+			field.Set(value)
+			continue
+		}
+
+		valueType := fieldType.Type
+		if isPtr {
+			valueType = valueType.Elem()
+		}
+
+		value, err := parseStringValue(valueType, raw)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("error parsing value for field %s: %w", fieldType.Name, err)
+		}
+
+		if isPtr {
+			p := reflect.New(value.Type())
+			p.Elem().Set(value)
+			value = p
+		}
+
+		field.Set(value)
+	}
+
+	return argsValue, nil
+}
+
+// getLeafOptions descends through any subcommand group and subcommand options to find the options for the invoked
+// subcommand.
+func getLeafOptions(
+	options []*discordgo.ApplicationCommandInteractionDataOption,
+) []*discordgo.ApplicationCommandInteractionDataOption {
+	for len(options) == 1 &&
+		(options[0].Type == discordgo.ApplicationCommandOptionSubCommand ||
+			options[0].Type == discordgo.ApplicationCommandOptionSubCommandGroup) {
+		options = options[0].Options
+	}
+
+	return options
+}
+
+// getOptionValue converts the value of a provided option into a value for the corresponding args struct field.
+func getOptionValue(
+	session *discordgo.Session,
+	interaction *discordgo.InteractionCreate,
+	resolved *discordgo.ApplicationCommandInteractionDataResolved,
+	option *discordgo.ApplicationCommandInteractionDataOption,
+	fieldType reflect.StructField,
+) (reflect.Value, error) {
+	var value reflect.Value
+
+	actualType := fieldType.Type
+	if actualType.Kind() == reflect.Ptr {
+		actualType = actualType.Elem()
+	}
+
+	switch option.Type { //nolint:exhaustive
+	case discordgo.ApplicationCommandOptionString:
+		value = reflect.ValueOf(option.StringValue())
+	case discordgo.ApplicationCommandOptionInteger:
+		if actualType.Kind() == reflect.Uint {
+			value = reflect.ValueOf(uint(option.IntValue()))
+		} else {
+			value = reflect.ValueOf(int(option.IntValue()))
+		}
+	case discordgo.ApplicationCommandOptionBoolean:
+		value = reflect.ValueOf(option.BoolValue())
+	case discordgo.ApplicationCommandOptionUser:
+		if actualType == reflect.TypeOf(discordgo.Member{}) {
+			userID, err := getOptionID(option)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			member := getResolvedMember(resolved, userID, interaction.GuildID)
+			if member == nil {
+				return reflect.Value{}, fmt.Errorf(
+					"%w: member %s for field %s",
+					ErrUnresolvedValue,
+					userID,
+					fieldType.Name,
+				)
+			}
+
+			value = reflect.ValueOf(*member)
+		} else {
+			user, err := resolveUser(session, resolved, option)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("error resolving value for field %s: %w", fieldType.Name, err)
+			}
+
+			value = reflect.ValueOf(*user)
+		}
+	case discordgo.ApplicationCommandOptionChannel:
+		channelTypes, err := getChannelTypes(fieldType)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		channel, err := resolveChannel(session, resolved, option, channelTypes != nil)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("error resolving value for field %s: %w", fieldType.Name, err)
+		}
+
+		err = checkChannelType(fieldType, channel.Type)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		value = reflect.ValueOf(*channel)
+	case discordgo.ApplicationCommandOptionRole:
+		role, err := resolveRole(session, resolved, option, interaction.GuildID)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("error resolving value for field %s: %w", fieldType.Name, err)
+		}
+
+		value = reflect.ValueOf(*role)
+	case discordgo.ApplicationCommandOptionNumber:
+		value = reflect.ValueOf(option.FloatValue())
+	case discordgo.ApplicationCommandOptionMentionable:
+		mentionableID, err := getOptionID(option)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		mentionable, err := resolveMentionable(resolved, mentionableID, interaction.GuildID)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("error resolving value for field %s: %w", fieldType.Name, err)
+		}
+
+		value = reflect.ValueOf(mentionable)
+	case discordgo.ApplicationCommandOptionAttachment:
+		attachmentID, err := getOptionID(option)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		attachment := resolved.Attachments[attachmentID]
+		if attachment == nil {
+			return reflect.Value{}, fmt.Errorf(
+				"%w: attachment %s for field %s",
+				ErrUnresolvedValue,
+				attachmentID,
+				fieldType.Name,
+			)
+		}
+
+		value = reflect.ValueOf(*attachment)
+	default:
+		return reflect.Value{}, fmt.Errorf("%w: %s", ErrUnsupportedOptionType, option.Type)
+	}
+
+	if value.Type() != actualType && value.Type() != getBaseType(actualType) {
+		return reflect.Value{}, fmt.Errorf(
+			"%w: option %s of type %s cannot be assigned to field %s",
+			ErrOptionTypeMismatch,
+			option.Name,
+			option.Type,
+			fieldType.Name,
+		)
+	}
+
+	// Convert values back into their named choice types
+	if value.Type() != actualType {
+		value = value.Convert(actualType)
+	}
+
+	return value, nil
+}
+
+// callHandler invokes a handler with the session, interaction and any further parameters, preceded by the context if
+// the handler accepts one, returning the error it returned, if any.
+func callHandler(
+	ctx context.Context,
+	handler any,
+	session *discordgo.Session,
+	interaction *discordgo.InteractionCreate,
+	params ...reflect.Value,
+) error {
+	params = append([]reflect.Value{reflect.ValueOf(session), reflect.ValueOf(interaction)}, params...)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	handlerType := reflect.TypeOf(handler)
+	if getParamOffset(handlerType) == 1 {
+		if handlerType.In(0) == switchboardContextType {
+			params = append([]reflect.Value{reflect.ValueOf(newContext(ctx, session, interaction))}, params...)
+		} else {
+			params = append([]reflect.Value{reflect.ValueOf(&ctx).Elem()}, params...)
+		}
+	}
+
+	results := reflect.ValueOf(handler).Call(params)
+
+	if len(results) == 1 && !results[0].IsNil() {
+		return results[0].Interface().(error)
+	}
+
+	return nil
+}
+
+func invokeSlashCommand(
+	ctx context.Context,
+	session *discordgo.Session,
+	interaction *discordgo.InteractionCreate,
+	handler any,
+	naming NamingStrategy,
+) error {
 	optionsMap := map[string]*discordgo.ApplicationCommandInteractionDataOption{}
 
-	for _, option := range interaction.ApplicationCommandData().Options {
+	for _, option := range getLeafOptions(interaction.ApplicationCommandData().Options) {
 		optionsMap[option.Name] = option
 	}
 
-	argsParamType := reflect.TypeOf(handler).In(2)
+	resolved := interaction.ApplicationCommandData().Resolved
+	if resolved == nil {
+		resolved = &discordgo.ApplicationCommandInteractionDataResolved{}
+	}
+
+	argsParamType := getArgsType(handler)
 	argsParamValue := reflect.New(argsParamType).Elem()
 
 	for index := 0; index < argsParamValue.NumField(); index++ {
 		field := argsParamValue.Field(index)
 		fieldType := argsParamType.Field(index)
 
-		option, optionProvided := optionsMap[strings.ToLower(fieldType.Name)]
+		option, optionProvided := optionsMap[getFieldName(fieldType, naming)]
 		if optionProvided {
-			var value reflect.Value
-
-			switch option.Type { //nolint:exhaustive
-			case discordgo.ApplicationCommandOptionString:
-				value = reflect.ValueOf(option.StringValue())
-			case discordgo.ApplicationCommandOptionInteger:
-				actualType := fieldType.Type
-				if actualType.Kind() == reflect.Ptr {
-					actualType = actualType.Elem()
-				}
-				if actualType == reflect.TypeOf(uint(0)) {
-					value = reflect.ValueOf(uint(option.IntValue()))
-				} else {
-					value = reflect.ValueOf(int(option.IntValue()))
-				}
-			case discordgo.ApplicationCommandOptionBoolean:
-				value = reflect.ValueOf(option.BoolValue())
-			// TODO: Is it fine to dereference users, roles, etc.?
-			case discordgo.ApplicationCommandOptionUser:
-				value = reflect.ValueOf(*option.UserValue(session))
-			case discordgo.ApplicationCommandOptionChannel:
-				value = reflect.ValueOf(*option.ChannelValue(session))
-			case discordgo.ApplicationCommandOptionRole:
-				value = reflect.ValueOf(*option.RoleValue(session, interaction.GuildID))
-			case discordgo.ApplicationCommandOptionNumber:
-				value = reflect.ValueOf(option.FloatValue())
-				// TODO: find how to get attachment val
-				//case discordgo.ApplicationCommandOptionAttachment:
-				//	value = reflect.ValueOf(*option.)
+			value, err := getOptionValue(session, interaction, resolved, option, fieldType)
+			if err != nil {
+				return err
 			}
 
 			if fieldType.Type.Kind() == reflect.Ptr {
@@ -241,49 +668,76 @@ func invokeSlashCommand(session *discordgo.Session, interaction *discordgo.Inter
 		} else if fieldType.Type.Kind() != reflect.Ptr {
 			value, err := getDefaultValue(fieldType)
 			if err != nil {
-				// TODO: Handle properly, not panic
-				panic(fmt.Errorf("error populating default value for field %s: %w", fieldType.Name, err))
+				return fmt.Errorf("error populating default value for field %s: %w", fieldType.Name, err)
 			}
 
 			field.Set(value)
 		}
 	}
 
-	reflect.ValueOf(handler).Call(
-		[]reflect.Value{
-			reflect.ValueOf(session),
-			reflect.ValueOf(interaction),
-			argsParamValue,
-		},
-	)
+	return callHandler(ctx, handler, session, interaction, argsParamValue)
 }
 
-func invokeMessageCommand(session *discordgo.Session, interaction *discordgo.InteractionCreate, handler any) {
-	msg := interaction.ApplicationCommandData().Resolved.Messages[interaction.ApplicationCommandData().TargetID]
+func invokeMessageCommand(
+	ctx context.Context,
+	session *discordgo.Session,
+	interaction *discordgo.InteractionCreate,
+	handler any,
+	_ NamingStrategy,
+) error {
+	data := interaction.ApplicationCommandData()
+	if data.Resolved == nil || data.Resolved.Messages[data.TargetID] == nil {
+		return fmt.Errorf("%w: target message %s", ErrUnresolvedValue, data.TargetID)
+	}
+	msg := data.Resolved.Messages[data.TargetID]
 
 	// I'm not fully certain why this isn't included
 	// TODO: See if there is a better solution for this
 	msg.GuildID = interaction.GuildID
 
-	reflect.ValueOf(handler).Call(
-		[]reflect.Value{
-			reflect.ValueOf(session),
-			reflect.ValueOf(interaction),
-			reflect.ValueOf(msg),
-		},
-	)
+	return callHandler(ctx, handler, session, interaction, reflect.ValueOf(msg))
+}
+
+func invokeUserCommand(
+	ctx context.Context,
+	session *discordgo.Session,
+	interaction *discordgo.InteractionCreate,
+	handler any,
+	_ NamingStrategy,
+) error {
+	data := interaction.ApplicationCommandData()
+	if data.Resolved == nil || data.Resolved.Users[data.TargetID] == nil {
+		return fmt.Errorf("%w: target user %s", ErrUnresolvedValue, data.TargetID)
+	}
+	user := data.Resolved.Users[data.TargetID]
+
+	params := []reflect.Value{reflect.ValueOf(user)}
+
+	if getHandlerParamCount(reflect.TypeOf(handler)) == 4 {
+		params = append(params, reflect.ValueOf(getResolvedMember(data.Resolved, data.TargetID, interaction.GuildID)))
+	}
+
+	return callHandler(ctx, handler, session, interaction, params...)
 }
 
-var invocationFuncs = map[CommandType]func(*discordgo.Session, *discordgo.InteractionCreate, any){
+var invocationFuncs = map[CommandType]func(
+	context.Context,
+	*discordgo.Session,
+	*discordgo.InteractionCreate,
+	any,
+	NamingStrategy,
+) error{
 	SlashCommand:   invokeSlashCommand,
 	MessageCommand: invokeMessageCommand,
+	UserCommand:    invokeUserCommand,
 }
 
 func invokeCommand(
+	ctx context.Context,
 	command *Command,
 	session *discordgo.Session,
 	interaction *discordgo.InteractionCreate,
 	handler any,
-) {
-	invocationFuncs[command.Type](session, interaction, handler)
+) error {
+	return invocationFuncs[command.Type](ctx, session, interaction, handler, command.getNamingStrategy())
 }
@@ -1,6 +1,7 @@
 package switchboard
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -24,6 +25,7 @@ func Test_getCommandOptions_WithAllValidOptionTypes(t *testing.T) {
 			Attachment discordgo.MessageAttachment `description:"Attachment argument"`
 		}) {
 		},
+		LowerCaseNaming,
 	)
 	if err != nil {
 		t.Errorf("got unexpected error getting command options: %s", err)
@@ -99,6 +101,7 @@ func Test_getCommandOptions_WithPointerOption(t *testing.T) {
 			Pointer *string `description:"Pointer"`
 		}) {
 		},
+		LowerCaseNaming,
 	)
 
 	if err != nil {
@@ -126,6 +129,7 @@ func Test_getCommandOptions_WithDefaultValue(t *testing.T) {
 			Default string `default:"default_val" description:"Default"`
 		}) {
 		},
+		LowerCaseNaming,
 	)
 
 	if err != nil {
@@ -150,6 +154,7 @@ func Test_getCommandOptions_WithDefaultValue(t *testing.T) {
 func Test_getCommandOptions_WithNoOptions(t *testing.T) {
 	options, err := getCommandOptions(
 		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct{}) {},
+		LowerCaseNaming,
 	)
 
 	if err != nil {
@@ -172,6 +177,7 @@ func Test_getCommandOptions_WithUintOption(t *testing.T) {
 			Count uint `description:"Count argument"`
 		}) {
 		},
+		LowerCaseNaming,
 	)
 
 	if err != nil {
@@ -202,6 +208,7 @@ func Test_getCommandOptions_WithUintPointerOption(t *testing.T) {
 			Count *uint `description:"Count argument"`
 		}) {
 		},
+		LowerCaseNaming,
 	)
 
 	if err != nil {
@@ -230,6 +237,7 @@ func Test_getCommandOptions_WithInvalidArgumentType(t *testing.T) {
 			Unsupported func()
 		}) {
 		},
+		LowerCaseNaming,
 	)
 
 	if err == nil {
@@ -454,6 +462,7 @@ func Test_invokeCommand_SlashCommand_WithNoArgs(t *testing.T) {
 	called := false
 
 	invokeCommand(
+		context.Background(),
 		&Command{
 			Type: SlashCommand,
 		},
@@ -509,11 +518,16 @@ func Test_invokeCommand_SlashCommand_WithProvidedArgs(t *testing.T) {
 						Type:  discordgo.ApplicationCommandOptionNumber,
 						Value: 12345.0,
 					},
-					//{
-					//	Name:  "attachment",
-					//	Type:  discordgo.ApplicationCommandOptionAttachment,
-					//	Value: "12345",
-					//},
+					{
+						Name:  "attachment",
+						Type:  discordgo.ApplicationCommandOptionAttachment,
+						Value: "12345",
+					},
+				},
+				Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+					Attachments: map[string]*discordgo.MessageAttachment{
+						"12345": {ID: "12345"},
+					},
 				},
 			},
 		},
@@ -522,17 +536,18 @@ func Test_invokeCommand_SlashCommand_WithProvidedArgs(t *testing.T) {
 	called := false
 
 	type Args struct {
-		String  string
-		Int     int
-		Bool    bool
-		User    discordgo.User
-		Channel discordgo.Channel
-		Role    discordgo.Role
-		Float   float64
-		//Attachment discordgo.MessageAttachment
+		String     string
+		Int        int
+		Bool       bool
+		User       discordgo.User
+		Channel    discordgo.Channel
+		Role       discordgo.Role
+		Float      float64
+		Attachment discordgo.MessageAttachment
 	}
 
 	invokeCommand(
+		context.Background(),
 		&Command{
 			Type: SlashCommand,
 		},
@@ -544,14 +559,14 @@ func Test_invokeCommand_SlashCommand_WithProvidedArgs(t *testing.T) {
 			if diff := deep.Equal(
 				args,
 				Args{
-					String:  "test",
-					Int:     12345,
-					Bool:    true,
-					User:    discordgo.User{ID: "12345"},
-					Channel: discordgo.Channel{ID: "12345"},
-					Role:    discordgo.Role{ID: "12345"},
-					Float:   12345.0,
-					//Attachment: discordgo.MessageAttachment{ID: "12345"},
+					String:     "test",
+					Int:        12345,
+					Bool:       true,
+					User:       discordgo.User{ID: "12345"},
+					Channel:    discordgo.Channel{ID: "12345"},
+					Role:       discordgo.Role{ID: "12345"},
+					Float:      12345.0,
+					Attachment: discordgo.MessageAttachment{ID: "12345"},
 				},
 			); diff != nil {
 				t.Error(diff)
@@ -586,6 +601,7 @@ func Test_invokeCommand_SlashCommand_WithUintArg(t *testing.T) {
 	}
 
 	invokeCommand(
+		context.Background(),
 		&Command{
 			Type: SlashCommand,
 		},
@@ -631,6 +647,7 @@ func Test_invokeCommand_MessageCommand(t *testing.T) {
 	called := false
 
 	invokeCommand(
+		context.Background(),
 		&Command{
 			Type: MessageCommand,
 		},
@@ -655,3 +672,498 @@ func Test_invokeCommand_MessageCommand(t *testing.T) {
 		t.Error("handler function not called")
 	}
 }
+
+func Test_invokeCommand_SlashCommand_WithSubCommandArgs(t *testing.T) {
+	interactionData := discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name: "admin",
+						Type: discordgo.ApplicationCommandOptionSubCommandGroup,
+						Options: []*discordgo.ApplicationCommandInteractionDataOption{
+							{
+								Name: "set",
+								Type: discordgo.ApplicationCommandOptionSubCommand,
+								Options: []*discordgo.ApplicationCommandInteractionDataOption{
+									{
+										Name:  "key",
+										Type:  discordgo.ApplicationCommandOptionString,
+										Value: "test",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	called := false
+
+	type Args struct {
+		Key string
+	}
+
+	invokeCommand(
+		context.Background(),
+		&Command{
+			Type: SlashCommand,
+		},
+		nil,
+		&interactionData,
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args Args) {
+			called = true
+
+			if diff := deep.Equal(
+				args,
+				Args{
+					Key: "test",
+				},
+			); diff != nil {
+				t.Error(diff)
+			}
+		})
+
+	if !called {
+		t.Error("handler function not called")
+	}
+}
+
+func Test_getCommandOptions_WithAutocompleteOption(t *testing.T) {
+	options, err := getCommandOptions(
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+			Item string `description:"Item" autocomplete:"items"`
+		}) {
+		},
+		LowerCaseNaming,
+	)
+
+	if err != nil {
+		t.Errorf("got unexpected error getting command options: %s", err)
+	}
+
+	if diff := deep.Equal(
+		options,
+		[]*discordgo.ApplicationCommandOption{
+			{
+				Name:         "item",
+				Required:     true,
+				Type:         discordgo.ApplicationCommandOptionString,
+				Description:  "Item",
+				Autocomplete: true,
+			},
+		},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func Test_getCommandOptions_WithAutocompleteOnUnsupportedType(t *testing.T) {
+	_, err := getCommandOptions(
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args struct {
+			Flag bool `description:"Flag" autocomplete:"flags"`
+		}) {
+		},
+		LowerCaseNaming,
+	)
+
+	if err == nil {
+		t.Error("did not get expected error when getting command options")
+	}
+	if !errors.Is(err, ErrAutocompleteUnsupportedType) {
+		t.Errorf("got unexpected error when getting command options: %s", err)
+	}
+}
+
+func Test_validateHandler_UserCommand_WithInvalidThird(t *testing.T) {
+	err := validateHandler(
+		UserCommand,
+		func(first *discordgo.Session, second *discordgo.InteractionCreate, third discordgo.User) {},
+	)
+
+	if err == nil {
+		t.Error("did not get expected error when validating handler")
+	}
+	if !errors.Is(err, ErrUserHandlerInvalidThirdParameterType) {
+		t.Errorf("got unexpected error when validating handler: %s", err)
+	}
+}
+
+func Test_validateHandler_UserCommand_WithInvalidFourth(t *testing.T) {
+	err := validateHandler(
+		UserCommand,
+		func(first *discordgo.Session, second *discordgo.InteractionCreate, third *discordgo.User, fourth bool) {
+		},
+	)
+
+	if err == nil {
+		t.Error("did not get expected error when validating handler")
+	}
+	if !errors.Is(err, ErrUserHandlerInvalidFourthParameterType) {
+		t.Errorf("got unexpected error when validating handler: %s", err)
+	}
+}
+
+func Test_validateHandler_UserCommand_WithValidHandler(t *testing.T) {
+	err := validateHandler(
+		UserCommand,
+		func(first *discordgo.Session, second *discordgo.InteractionCreate, third *discordgo.User) {},
+	)
+
+	if err != nil {
+		t.Errorf("got unexpected error when validating handler: %s", err)
+	}
+
+	err = validateHandler(
+		UserCommand,
+		func(
+			first *discordgo.Session,
+			second *discordgo.InteractionCreate,
+			third *discordgo.User,
+			fourth *discordgo.Member,
+		) {
+		},
+	)
+
+	if err != nil {
+		t.Errorf("got unexpected error when validating handler: %s", err)
+	}
+}
+
+func Test_invokeCommand_UserCommand(t *testing.T) {
+	interactionData := discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				TargetID: "1",
+
+				Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+					Users: map[string]*discordgo.User{
+						"1": {ID: "1", Username: "Test"},
+					},
+					Members: map[string]*discordgo.Member{
+						"1": {Nick: "Tester"},
+					},
+				},
+			},
+			GuildID: "2",
+		},
+	}
+
+	called := false
+
+	invokeCommand(
+		context.Background(),
+		&Command{
+			Type: UserCommand,
+		},
+		&discordgo.Session{},
+		&interactionData,
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member) {
+			called = true
+
+			if diff := deep.Equal(user, &discordgo.User{ID: "1", Username: "Test"}); diff != nil {
+				t.Error(diff)
+			}
+
+			if diff := deep.Equal(
+				member,
+				&discordgo.Member{
+					GuildID: "2",
+					Nick:    "Tester",
+					User:    &discordgo.User{ID: "1", Username: "Test"},
+				},
+			); diff != nil {
+				t.Error(diff)
+			}
+		})
+
+	if !called {
+		t.Error("handler function not called")
+	}
+}
+
+func Test_invokeCommand_UserCommand_InDM(t *testing.T) {
+	interactionData := discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				TargetID: "1",
+
+				Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+					Users: map[string]*discordgo.User{
+						"1": {ID: "1", Username: "Test"},
+					},
+				},
+			},
+		},
+	}
+
+	called := false
+
+	invokeCommand(
+		context.Background(),
+		&Command{
+			Type: UserCommand,
+		},
+		&discordgo.Session{},
+		&interactionData,
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, user *discordgo.User, member *discordgo.Member) {
+			called = true
+
+			if member != nil {
+				t.Errorf("got unexpected member: %#v", member)
+			}
+		})
+
+	if !called {
+		t.Error("handler function not called")
+	}
+}
+
+func Test_invokeCommand_SlashCommand_WithOptionalAttachmentArgs(t *testing.T) {
+	interactionData := discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name:  "provided",
+						Type:  discordgo.ApplicationCommandOptionAttachment,
+						Value: "12345",
+					},
+				},
+				Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+					Attachments: map[string]*discordgo.MessageAttachment{
+						"12345": {ID: "12345", Filename: "test.png"},
+					},
+				},
+			},
+		},
+	}
+
+	called := false
+
+	type Args struct {
+		Provided *discordgo.MessageAttachment
+		Omitted  *discordgo.MessageAttachment
+	}
+
+	err := invokeCommand(
+		context.Background(),
+		&Command{
+			Type: SlashCommand,
+		},
+		nil,
+		&interactionData,
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args Args) {
+			called = true
+
+			if diff := deep.Equal(
+				args,
+				Args{
+					Provided: &discordgo.MessageAttachment{ID: "12345", Filename: "test.png"},
+				},
+			); diff != nil {
+				t.Error(diff)
+			}
+		})
+
+	if err != nil {
+		t.Errorf("got unexpected error: %s", err)
+	}
+	if !called {
+		t.Error("handler function not called")
+	}
+}
+
+func Test_invokeCommand_SlashCommand_WithUnresolvedAttachment(t *testing.T) {
+	interactionData := discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name:  "attachment",
+						Type:  discordgo.ApplicationCommandOptionAttachment,
+						Value: "12345",
+					},
+				},
+			},
+		},
+	}
+
+	type Args struct {
+		Attachment discordgo.MessageAttachment
+	}
+
+	err := invokeCommand(
+		context.Background(),
+		&Command{
+			Type: SlashCommand,
+		},
+		nil,
+		&interactionData,
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args Args) {
+			t.Error("handler function unexpectedly called")
+		})
+
+	if err == nil {
+		t.Error("did not get expected error")
+	}
+	if !errors.Is(err, ErrUnresolvedValue) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
+
+func Test_invokeCommand_SlashCommand_WithMemberArg(t *testing.T) {
+	interactionData := discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name:  "target",
+						Type:  discordgo.ApplicationCommandOptionUser,
+						Value: "1",
+					},
+					{
+						Name:  "other",
+						Type:  discordgo.ApplicationCommandOptionUser,
+						Value: "2",
+					},
+				},
+				Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+					Users: map[string]*discordgo.User{
+						"1": {ID: "1", Username: "Test"},
+						"2": {ID: "2", Username: "Other"},
+					},
+					Members: map[string]*discordgo.Member{
+						"1": {Nick: "Tester", Roles: []string{"4"}},
+					},
+				},
+			},
+			GuildID: "3",
+		},
+	}
+
+	type Args struct {
+		Target discordgo.Member
+		Other  *discordgo.Member
+	}
+
+	err := invokeCommand(
+		context.Background(),
+		&Command{
+			Type: SlashCommand,
+		},
+		nil,
+		&interactionData,
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args Args) {
+			t.Error("handler function unexpectedly called")
+		})
+
+	if err == nil {
+		t.Error("did not get expected error")
+	}
+	if !errors.Is(err, ErrUnresolvedValue) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+
+	interactionData.ApplicationCommandData().Resolved.Members["2"] = &discordgo.Member{Nick: "Other"}
+	called := false
+
+	err = invokeCommand(
+		context.Background(),
+		&Command{
+			Type: SlashCommand,
+		},
+		nil,
+		&interactionData,
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args Args) {
+			called = true
+
+			if diff := deep.Equal(
+				args,
+				Args{
+					Target: discordgo.Member{
+						GuildID: "3",
+						Nick:    "Tester",
+						Roles:   []string{"4"},
+						User:    &discordgo.User{ID: "1", Username: "Test"},
+					},
+					Other: &discordgo.Member{
+						GuildID: "3",
+						Nick:    "Other",
+						User:    &discordgo.User{ID: "2", Username: "Other"},
+					},
+				},
+			); diff != nil {
+				t.Error(diff)
+			}
+		})
+
+	if err != nil {
+		t.Errorf("got unexpected error: %s", err)
+	}
+	if !called {
+		t.Error("handler function not called")
+	}
+}
+
+func Test_validateHandler_SlashCommand_WithErrorReturn(t *testing.T) {
+	err := validateHandler(
+		SlashCommand,
+		func(first *discordgo.Session, second *discordgo.InteractionCreate, third struct{}) error { return nil },
+	)
+
+	if err != nil {
+		t.Errorf("got unexpected error when validating handler: %s", err)
+	}
+}
+
+func Test_validateHandler_SlashCommand_WithInvalidReturn(t *testing.T) {
+	for _, handler := range []any{
+		func(first *discordgo.Session, second *discordgo.InteractionCreate, third struct{}) bool { return false },
+		func(first *discordgo.Session, second *discordgo.InteractionCreate, third struct{}) (string, error) {
+			return "", nil
+		},
+	} {
+		err := validateHandler(SlashCommand, handler)
+
+		if err == nil {
+			t.Error("did not get expected error when validating handler")
+		}
+		if !errors.Is(err, ErrHandlerInvalidReturnType) {
+			t.Errorf("got unexpected error when validating handler: %s", err)
+		}
+	}
+}
+
+func Test_invokeCommand_SlashCommand_WithHandlerError(t *testing.T) {
+	interactionData := discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{},
+		},
+	}
+	handlerErr := errors.New("handler error")
+
+	err := invokeCommand(
+		context.Background(),
+		&Command{
+			Type: SlashCommand,
+		},
+		nil,
+		&interactionData,
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) error {
+			return handlerErr
+		})
+
+	if !errors.Is(err, handlerErr) {
+		t.Errorf("got unexpected error: %v", err)
+	}
+}
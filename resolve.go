@@ -0,0 +1,118 @@
+package switchboard
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Values for user, channel and role options are resolved from the interaction's resolved data where possible, only
+// falling back to the session's state or the REST API when Discord did not include them. This allows handlers to
+// receive complete values without a state cache, such as when receiving interactions over HTTP.
+
+func getOptionID(option *discordgo.ApplicationCommandInteractionDataOption) (string, error) {
+	id, isString := option.Value.(string)
+	if !isString || id == "" {
+		return "", fmt.Errorf("%w: option %s has no ID", ErrUnresolvedValue, option.Name)
+	}
+
+	return id, nil
+}
+
+// getResolvedMember looks up a member from an interaction's resolved data, returning nil if the member was not
+// resolved (such as when the interaction took place outside of a guild).
+func getResolvedMember(
+	resolved *discordgo.ApplicationCommandInteractionDataResolved,
+	id string,
+	guildID string,
+) *discordgo.Member {
+	member := resolved.Members[id]
+	if member == nil {
+		return nil
+	}
+
+	// Resolved members do not include their user, so populate it from the resolved users
+	member.User = resolved.Users[id]
+	member.GuildID = guildID
+
+	return member
+}
+
+func resolveUser(
+	session *discordgo.Session,
+	resolved *discordgo.ApplicationCommandInteractionDataResolved,
+	option *discordgo.ApplicationCommandInteractionDataOption,
+) (*discordgo.User, error) {
+	id, err := getOptionID(option)
+	if err != nil {
+		return nil, err
+	}
+
+	if user := resolved.Users[id]; user != nil {
+		return user, nil
+	}
+
+	if user := option.UserValue(session); user != nil {
+		return user, nil
+	}
+
+	return nil, fmt.Errorf("%w: user %s", ErrUnresolvedValue, id)
+}
+
+// resolveChannel resolves the channel provided for an option. If requireType is set, only channels whose type is known
+// are returned - that is, those from the resolved data, the session's state or the REST API - as the caller needs to
+// check the channel's type. Otherwise, a channel with only its ID populated is returned if it can't be looked up.
+func resolveChannel(
+	session *discordgo.Session,
+	resolved *discordgo.ApplicationCommandInteractionDataResolved,
+	option *discordgo.ApplicationCommandInteractionDataOption,
+	requireType bool,
+) (*discordgo.Channel, error) {
+	id, err := getOptionID(option)
+	if err != nil {
+		return nil, err
+	}
+
+	if channel := resolved.Channels[id]; channel != nil {
+		return channel, nil
+	}
+
+	if session != nil {
+		if channel, err := session.State.Channel(id); err == nil {
+			return channel, nil
+		}
+
+		if channel, err := session.Channel(id); err == nil {
+			return channel, nil
+		}
+	}
+
+	if requireType {
+		return nil, fmt.Errorf("%w: channel %s", ErrUnresolvedValue, id)
+	}
+
+	// Matches the behaviour of discordgo's ChannelValue, which this previously used
+	return &discordgo.Channel{ID: id}, nil
+}
+
+func resolveRole(
+	session *discordgo.Session,
+	resolved *discordgo.ApplicationCommandInteractionDataResolved,
+	option *discordgo.ApplicationCommandInteractionDataOption,
+	guildID string,
+) (*discordgo.Role, error) {
+	id, err := getOptionID(option)
+	if err != nil {
+		return nil, err
+	}
+
+	if role := resolved.Roles[id]; role != nil {
+		return role, nil
+	}
+
+	if role := option.RoleValue(session, guildID); role != nil {
+		return role, nil
+	}
+
+	return nil, fmt.Errorf("%w: role %s", ErrUnresolvedValue, id)
+}
@@ -0,0 +1,202 @@
+package switchboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func Test_invokeCommand_SlashCommand_WithResolvedArgs(t *testing.T) {
+	interactionData := discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name:  "user",
+						Type:  discordgo.ApplicationCommandOptionUser,
+						Value: "1",
+					},
+					{
+						Name:  "channel",
+						Type:  discordgo.ApplicationCommandOptionChannel,
+						Value: "2",
+					},
+					{
+						Name:  "role",
+						Type:  discordgo.ApplicationCommandOptionRole,
+						Value: "3",
+					},
+				},
+				Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+					Users: map[string]*discordgo.User{
+						"1": {ID: "1", Username: "Test"},
+					},
+					Channels: map[string]*discordgo.Channel{
+						"2": {ID: "2", Name: "general"},
+					},
+					Roles: map[string]*discordgo.Role{
+						"3": {ID: "3", Name: "Moderators"},
+					},
+				},
+			},
+			GuildID: "4",
+		},
+	}
+
+	called := false
+
+	type Args struct {
+		User    discordgo.User
+		Channel *discordgo.Channel
+		Role    discordgo.Role
+	}
+
+	// A session without any state is provided to ensure values are taken from the resolved data rather than fetched
+	err := invokeCommand(
+		context.Background(),
+		&Command{
+			Type: SlashCommand,
+		},
+		&discordgo.Session{},
+		&interactionData,
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args Args) {
+			called = true
+
+			if diff := deep.Equal(
+				args,
+				Args{
+					User:    discordgo.User{ID: "1", Username: "Test"},
+					Channel: &discordgo.Channel{ID: "2", Name: "general"},
+					Role:    discordgo.Role{ID: "3", Name: "Moderators"},
+				},
+			); diff != nil {
+				t.Error(diff)
+			}
+		})
+
+	if err != nil {
+		t.Errorf("got unexpected error: %s", err)
+	}
+	if !called {
+		t.Error("handler function not called")
+	}
+}
+
+func Test_invokeCommand_SlashCommand_WithMissingOptionID(t *testing.T) {
+	interactionData := discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name: "role",
+						Type: discordgo.ApplicationCommandOptionRole,
+					},
+				},
+			},
+		},
+	}
+
+	type Args struct {
+		Role discordgo.Role
+	}
+
+	err := invokeCommand(
+		context.Background(),
+		&Command{
+			Type: SlashCommand,
+		},
+		nil,
+		&interactionData,
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args Args) {
+			t.Error("handler function unexpectedly called")
+		})
+
+	if err == nil {
+		t.Error("did not get expected error")
+	}
+	if !errors.Is(err, ErrUnresolvedValue) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
+
+func Test_invokeCommand_SlashCommand_WithMismatchedOptionType(t *testing.T) {
+	interactionData := discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name:  "name",
+						Type:  discordgo.ApplicationCommandOptionInteger,
+						Value: 1.0,
+					},
+				},
+			},
+		},
+	}
+
+	type Args struct {
+		Name string
+	}
+
+	err := invokeCommand(
+		context.Background(),
+		&Command{
+			Type: SlashCommand,
+		},
+		nil,
+		&interactionData,
+		func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args Args) {
+			t.Error("handler function unexpectedly called")
+		})
+
+	if err == nil {
+		t.Error("did not get expected error")
+	}
+	if !errors.Is(err, ErrOptionTypeMismatch) {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
+
+func Test_invokeCommand_WithUnresolvedTarget(t *testing.T) {
+	for _, testCase := range []struct {
+		commandType CommandType
+		handler     any
+	}{
+		{
+			commandType: MessageCommand,
+			handler:     func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ *discordgo.Message) {},
+		},
+		{
+			commandType: UserCommand,
+			handler:     func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ *discordgo.User) {},
+		},
+	} {
+		for _, resolved := range []*discordgo.ApplicationCommandInteractionDataResolved{
+			nil,
+			{},
+		} {
+			err := invokeCommand(
+				context.Background(),
+				&Command{Type: testCase.commandType},
+				&discordgo.Session{},
+				&discordgo.InteractionCreate{
+					Interaction: &discordgo.Interaction{
+						Type: discordgo.InteractionApplicationCommand,
+						Data: discordgo.ApplicationCommandInteractionData{TargetID: "1", Resolved: resolved},
+					},
+				},
+				testCase.handler,
+			)
+
+			if !errors.Is(err, ErrUnresolvedValue) {
+				t.Errorf("got unexpected error for command type %d: %v", testCase.commandType, err)
+			}
+		}
+	}
+}
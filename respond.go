@@ -0,0 +1,192 @@
+package switchboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// defaultAutoDeferAfter is how long a handler is given to respond before the response is deferred, if not configured.
+const defaultAutoDeferAfter = 2 * time.Second
+
+// AutoDefer configures a command to automatically defer its response if its handler is slow to respond. Once the
+// response has been deferred, responses sent by the handler using Respond are transparently turned into edits of the
+// deferred response.
+type AutoDefer struct {
+	// How long the handler is given to respond before the response is deferred. Defaults to two seconds.
+	After time.Duration
+
+	// Whether the deferred response should be ephemeral. As the visibility of a response cannot be changed after it has
+	// been sent, this also determines the visibility of the handler's eventual response.
+	Ephemeral bool
+}
+
+type responseState int
+
+const (
+	responseStatePending responseState = iota
+	responseStateDeferred
+	responseStateResponded
+)
+
+type responderKey struct{}
+
+// responder tracks the initial response to an interaction, ensuring that responses sent after the interaction has
+// been deferred are sent as edits instead.
+type responder struct {
+	mutex       sync.Mutex
+	session     *discordgo.Session
+	interaction *discordgo.Interaction
+	state       responseState
+	timer       *time.Timer
+}
+
+func newResponder(session *discordgo.Session, interaction *discordgo.InteractionCreate) *responder {
+	return &responder{session: session, interaction: interaction.Interaction}
+}
+
+func getResponder(ctx context.Context) (*responder, error) {
+	r, ok := ctx.Value(responderKey{}).(*responder)
+	if !ok {
+		return nil, ErrNoInteractionContext
+	}
+
+	return r, nil
+}
+
+// getWebhookEdit converts the data of an interaction response into an edit of an existing response.
+func getWebhookEdit(data *discordgo.InteractionResponseData) *discordgo.WebhookEdit {
+	if data == nil {
+		return &discordgo.WebhookEdit{}
+	}
+
+	return &discordgo.WebhookEdit{
+		Content:         &data.Content,
+		Components:      &data.Components,
+		Embeds:          &data.Embeds,
+		Files:           data.Files,
+		AllowedMentions: data.AllowedMentions,
+	}
+}
+
+func (r *responder) respond(response *discordgo.InteractionResponse) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.stopAutoDefer()
+
+	switch r.state {
+	case responseStateResponded:
+		return ErrAlreadyResponded
+	case responseStateDeferred:
+		if response.Type != discordgo.InteractionResponseChannelMessageWithSource &&
+			response.Type != discordgo.InteractionResponseUpdateMessage {
+			return fmt.Errorf("%w: response type %d", ErrResponseAfterDefer, response.Type)
+		}
+
+		_, err := r.session.InteractionResponseEdit(r.interaction, getWebhookEdit(response.Data))
+		if err != nil {
+			return err
+		}
+	case responseStatePending:
+		err := r.session.InteractionRespond(r.interaction, response)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.state = responseStateResponded
+
+	return nil
+}
+
+// deferResponse defers the response to the interaction, returning ErrAlreadyResponded if it has already been deferred
+// or responded to.
+func (r *responder) deferResponse(ephemeral bool) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.stopAutoDefer()
+
+	if r.state != responseStatePending {
+		return ErrAlreadyResponded
+	}
+
+	response := &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}
+	if ephemeral {
+		response.Data = &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral}
+	}
+
+	err := r.session.InteractionRespond(r.interaction, response)
+	if err != nil {
+		return fmt.Errorf("error deferring response: %w", err)
+	}
+
+	r.state = responseStateDeferred
+
+	return nil
+}
+
+// startAutoDefer schedules the response to be deferred if it has not been sent by the configured time, reporting any
+// error deferring the response to onError.
+func (r *responder) startAutoDefer(config *AutoDefer, onError func(error)) {
+	after := config.After
+	if after <= 0 {
+		after = defaultAutoDeferAfter
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.timer = time.AfterFunc(after, func() {
+		err := r.deferResponse(config.Ephemeral)
+		if err != nil && !errors.Is(err, ErrAlreadyResponded) {
+			onError(err)
+		}
+	})
+}
+
+// stopAutoDefer cancels any scheduled deferral. Callers must hold the mutex.
+func (r *responder) stopAutoDefer() {
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+}
+
+// checkResponded returns ErrNotResponded if the interaction has not yet been deferred or responded to.
+func (r *responder) checkResponded() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.state == responseStatePending {
+		return ErrNotResponded
+	}
+
+	return nil
+}
+
+// close cancels any scheduled deferral once the interaction has been handled.
+func (r *responder) close() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.stopAutoDefer()
+}
+
+// Respond sends the initial response to the interaction being handled with the given context. If the response has
+// already been deferred, such as by AutoDefer, message responses are instead sent as an edit of the deferred response.
+func Respond(ctx context.Context, response *discordgo.InteractionResponse) error {
+	r, err := getResponder(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.respond(response)
+}
@@ -0,0 +1,225 @@
+package switchboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+type recordedRequest struct {
+	Method  string
+	Path    string
+	Body    map[string]any
+	RawBody string
+}
+
+// newTestSession creates a session whose requests are recorded rather than sent to Discord. Responses are generated
+// by the given function, or are empty JSON objects if it is nil.
+func newTestSession(
+	t *testing.T,
+	respond func(request *http.Request) (int, string),
+) (*discordgo.Session, func() []recordedRequest) {
+	t.Helper()
+
+	session, err := discordgo.New("Bot token")
+	if err != nil {
+		t.Fatalf("error creating session: %s", err)
+	}
+
+	var mutex sync.Mutex
+	var requests []recordedRequest
+
+	session.Client = &http.Client{
+		Transport: roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			recorded := recordedRequest{Method: request.Method, Path: request.URL.Path}
+			if request.Body != nil {
+				body, _ := io.ReadAll(request.Body)
+				recorded.RawBody = string(body)
+				_ = json.Unmarshal(body, &recorded.Body)
+			}
+
+			mutex.Lock()
+			requests = append(requests, recorded)
+			mutex.Unlock()
+
+			status, body := http.StatusOK, "{}"
+			if respond != nil {
+				status, body = respond(request)
+			}
+
+			return &http.Response{
+				StatusCode: status,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    request,
+			}, nil
+		}),
+	}
+
+	return session, func() []recordedRequest {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return requests
+	}
+}
+
+func newTestCommandInteraction(name string) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			ID:      "1",
+			AppID:   "2",
+			Token:   "token",
+			Type:    discordgo.InteractionApplicationCommand,
+			Data:    discordgo.ApplicationCommandInteractionData{Name: name},
+			Version: 1,
+		},
+	}
+}
+
+func TestRespond(t *testing.T) {
+	session, getRequests := newTestSession(t, nil)
+	var respondErr error
+
+	s := &Switchboard{}
+	_ = s.AddCommand(&Command{
+		Name:        "test",
+		Description: "Test command",
+		AutoDefer:   &AutoDefer{After: time.Minute},
+		Handler: func(ctx context.Context, _ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {
+			respondErr = Respond(ctx, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{Content: "Hello world!"},
+			})
+		},
+	})
+
+	s.HandleInteractionCreate(session, newTestCommandInteraction("test"))
+
+	if respondErr != nil {
+		t.Errorf("got unexpected error when responding: %s", respondErr)
+	}
+
+	requests := getRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+	if diff := deep.Equal(
+		[]any{requests[0].Path, requests[0].Body["type"]},
+		[]any{"/api/v9/interactions/1/token/callback", float64(discordgo.InteractionResponseChannelMessageWithSource)},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRespond_WithAutoDefer(t *testing.T) {
+	session, getRequests := newTestSession(t, nil)
+	var respondErr error
+
+	s := &Switchboard{}
+	_ = s.AddCommand(&Command{
+		Name:        "test",
+		Description: "Test command",
+		AutoDefer:   &AutoDefer{After: time.Millisecond, Ephemeral: true},
+		Handler: func(ctx context.Context, _ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {
+			time.Sleep(50 * time.Millisecond)
+
+			respondErr = Respond(ctx, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{Content: "Hello world!"},
+			})
+		},
+	})
+
+	s.HandleInteractionCreate(session, newTestCommandInteraction("test"))
+
+	if respondErr != nil {
+		t.Errorf("got unexpected error when responding: %s", respondErr)
+	}
+
+	requests := getRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+
+	if diff := deep.Equal(
+		[]any{requests[0].Method, requests[0].Path, requests[0].Body["type"], requests[0].Body["data"]},
+		[]any{
+			http.MethodPost,
+			"/api/v9/interactions/1/token/callback",
+			float64(discordgo.InteractionResponseDeferredChannelMessageWithSource),
+			map[string]any{
+				"tts":        false,
+				"content":    "",
+				"components": nil,
+				"embeds":     nil,
+				"flags":      float64(discordgo.MessageFlagsEphemeral),
+			},
+		},
+	); diff != nil {
+		t.Error(diff)
+	}
+
+	if diff := deep.Equal(
+		[]any{requests[1].Method, requests[1].Path, requests[1].Body["content"]},
+		[]any{http.MethodPatch, "/api/v9/webhooks/2/token/messages/@original", "Hello world!"},
+	); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRespond_WithModalAfterDefer(t *testing.T) {
+	session, _ := newTestSession(t, nil)
+	r := newResponder(session, newTestCommandInteraction("test"))
+
+	err := r.deferResponse(false)
+	if err != nil {
+		t.Fatalf("got unexpected error when deferring: %s", err)
+	}
+
+	err = r.respond(&discordgo.InteractionResponse{Type: discordgo.InteractionResponseModal})
+	if !errors.Is(err, ErrResponseAfterDefer) {
+		t.Errorf("got unexpected error: %v", err)
+	}
+}
+
+func TestRespond_WithExistingResponse(t *testing.T) {
+	session, _ := newTestSession(t, nil)
+	ctx := context.WithValue(
+		context.Background(),
+		responderKey{},
+		newResponder(session, newTestCommandInteraction("test")),
+	)
+	response := &discordgo.InteractionResponse{Type: discordgo.InteractionResponseChannelMessageWithSource}
+
+	err := Respond(ctx, response)
+	if err != nil {
+		t.Fatalf("got unexpected error when responding: %s", err)
+	}
+
+	err = Respond(ctx, response)
+	if !errors.Is(err, ErrAlreadyResponded) {
+		t.Errorf("got unexpected error: %v", err)
+	}
+}
+
+func TestRespond_WithoutInteractionContext(t *testing.T) {
+	err := Respond(context.Background(), &discordgo.InteractionResponse{})
+	if !errors.Is(err, ErrNoInteractionContext) {
+		t.Errorf("got unexpected error: %v", err)
+	}
+}
@@ -1,63 +1,180 @@
 package switchboard
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"sync"
 
 	"github.com/bwmarrin/discordgo"
 )
 
+// ErrorHandler is called with any error which occurs while handling an interaction, including errors returned by
+// handlers.
+type ErrorHandler func(session *discordgo.Session, interaction *discordgo.InteractionCreate, err error)
+
 type Switchboard struct {
-	commands []*Command
+	ErrorHandler ErrorHandler
+
+	// A message sent to the user, visible only to them, when handling their interaction panics. If empty, no message is
+	// sent.
+	PanicMessage string
+
+	// Scopes to remove stale commands from when syncing. If nil, only scopes with commands registered with the
+	// Switchboard are synced.
+	Prune *PruneOptions
+
+	// The NamingStrategy used to derive option and text input names from args struct fields without a name tag. If nil,
+	// DefaultNamingStrategy is used. The strategy is captured when commands and handlers are added, so it should be set
+	// before adding any.
+	NamingStrategy NamingStrategy
+
+	commands              []*Command
+	autocompleteProviders map[string]AutocompleteProvider
+	componentRoutes       []*customIDRoute
+	modalRoutes           []*customIDRoute
+	middleware            []Middleware
+
+	contextOnce sync.Once
+	ctx         context.Context
+	cancel      context.CancelFunc
 }
 
-func (s *Switchboard) handleInteractionApplicationCommand(
-	session *discordgo.Session,
-	interaction *discordgo.InteractionCreate,
-) error {
+// getNamingStrategy returns the NamingStrategy to capture for commands and handlers added to the Switchboard.
+func (s *Switchboard) getNamingStrategy() NamingStrategy {
+	if s.NamingStrategy != nil {
+		return s.NamingStrategy
+	}
+
+	return DefaultNamingStrategy
+}
+
+// matchesCommandType determines whether an interaction could target a command of the given type. discordgo does not
+// expose the type of the targeted command, so it is inferred from the interaction's target - slash commands have none,
+// while context menu commands have their target included in the resolved messages or users.
+func matchesCommandType(data discordgo.ApplicationCommandInteractionData, commandType CommandType) bool {
+	if data.TargetID == "" {
+		return commandType == SlashCommand
+	}
+
+	if data.Resolved != nil {
+		if data.Resolved.Messages[data.TargetID] != nil {
+			return commandType == MessageCommand
+		}
+		if data.Resolved.Users[data.TargetID] != nil {
+			return commandType == UserCommand
+		}
+	}
+
+	// The target wasn't resolved, so either type of context menu command could be targeted
+	return commandType == MessageCommand || commandType == UserCommand
+}
+
+func (s *Switchboard) findCommand(interaction *discordgo.InteractionCreate) (*Command, error) {
+	data := interaction.ApplicationCommandData()
+
 	for _, command := range s.commands {
-		if command.Name == interaction.ApplicationCommandData().Name &&
+		if command.Name == data.Name &&
+			matchesCommandType(data, command.Type) &&
 			(command.GuildID == "" || command.GuildID == interaction.GuildID) {
-			invokeCommand(command, session, interaction, command.Handler)
-			return nil
+			return command, nil
 		}
 	}
 
-	return ErrUnknownCommand
+	return nil, ErrUnknownCommand
 }
 
-func (s *Switchboard) HandleInteractionCreate(session *discordgo.Session, interaction *discordgo.InteractionCreate) {
-	switch interaction.Type { //nolint:exhaustive
-	case discordgo.InteractionApplicationCommand:
-		s.handleInteractionApplicationCommand(session, interaction) //nolint:errcheck
-	default:
-		// TODO: Figure out error handling
+func (s *Switchboard) handleInteractionApplicationCommand(invocation *Invocation) error {
+	handler, err := invocation.Command.getHandler(invocation.Interaction.ApplicationCommandData().Options)
+	if err != nil {
+		return err
 	}
+
+	return invokeCommand(invocation.Context, invocation.Command, invocation.Session, invocation.Interaction, handler)
 }
 
-func (s *Switchboard) AddCommand(command *Command) error {
-	// TODO: Add checks for things like duplicate commands, and ensure added command is valid
-	s.commands = append(s.commands, command)
+// getInvocationHandler determines how an invocation should be handled, populating its command if it targets one.
+func (s *Switchboard) getInvocationHandler(invocation *Invocation) (InvocationHandler, error) {
+	switch invocation.Interaction.Type { //nolint:exhaustive
+	case discordgo.InteractionApplicationCommand, discordgo.InteractionApplicationCommandAutocomplete:
+		command, err := s.findCommand(invocation.Interaction)
+		if err != nil {
+			return nil, err
+		}
+		invocation.Command = command
 
-	return nil
+		if invocation.Interaction.Type == discordgo.InteractionApplicationCommandAutocomplete {
+			return applyMiddleware(s.handleInteractionAutocomplete, command.Middleware), nil
+		}
+		return applyMiddleware(s.handleInteractionApplicationCommand, command.Middleware), nil
+	case discordgo.InteractionMessageComponent:
+		return s.handleInteractionMessageComponent, nil
+	case discordgo.InteractionModalSubmit:
+		return s.handleInteractionModalSubmit, nil
+	default:
+		return nil, ErrUnsupportedInteractionType
+	}
 }
 
-func (s *Switchboard) SyncCommands(session *discordgo.Session, appId string) error {
-	guildCommands := map[string][]*discordgo.ApplicationCommand{}
+func (s *Switchboard) HandleInteractionCreate(session *discordgo.Session, interaction *discordgo.InteractionCreate) {
+	ctx, cancel := s.newInteractionContext(interaction)
+	defer cancel()
+
+	r := newResponder(session, interaction)
+	defer r.close()
 
-	for _, command := range s.commands {
-		discordCommand, err := command.ToDiscordCommand()
-		if err != nil {
-			return fmt.Errorf("error generating discord command for command %s: %w", command.Name, err)
+	ctx = context.WithValue(ctx, responderKey{}, r)
+	ctx = context.WithValue(ctx, namingStrategyKey{}, s.getNamingStrategy())
+
+	invocation := &Invocation{
+		Context:     ctx,
+		Session:     session,
+		Interaction: interaction,
+	}
+
+	handler, err := s.getInvocationHandler(invocation)
+	if err != nil {
+		// The error is returned from within the middleware, so that it is observed by the Switchboard's middleware
+		resolveErr := err
+		handler = func(*Invocation) error {
+			return resolveErr
 		}
-		guildCommands[command.GuildID] = append(guildCommands[command.GuildID], discordCommand)
+	} else if interaction.Type == discordgo.InteractionApplicationCommand && invocation.Command.AutoDefer != nil {
+		r.startAutoDefer(invocation.Command.AutoDefer, func(err error) {
+			s.handleError(session, interaction, err)
+		})
 	}
 
-	for guildId, commands := range guildCommands {
-		_, err := session.ApplicationCommandBulkOverwrite(appId, guildId, commands)
+	err = callRecovering(applyMiddleware(handler, s.middleware), invocation)
+	if err != nil {
+		s.handleError(session, interaction, err)
+	}
+
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		err = s.sendPanicMessage(r, interaction)
 		if err != nil {
-			return fmt.Errorf("error syncing commands for guild %s: %w", guildId, err)
+			s.handleError(session, interaction, err)
 		}
 	}
+}
+
+func (s *Switchboard) handleError(session *discordgo.Session, interaction *discordgo.InteractionCreate, err error) {
+	if s.ErrorHandler != nil {
+		s.ErrorHandler(session, interaction, err)
+	}
+}
+
+// AddCommand registers a command with the Switchboard, after validating it. If the command is invalid, a
+// *ValidationError listing every problem with it is returned.
+func (s *Switchboard) AddCommand(command *Command) error {
+	command.naming = s.getNamingStrategy()
+
+	err := s.validateCommand(command)
+	if err != nil {
+		return err
+	}
+
+	s.commands = append(s.commands, command)
 
 	return nil
 }
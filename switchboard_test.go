@@ -0,0 +1,158 @@
+package switchboard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func TestSwitchboard_HandleInteractionCreate_WithErrorHandler(t *testing.T) {
+	handlerErr := errors.New("handler error")
+	var receivedErrs []error
+
+	s := &Switchboard{
+		ErrorHandler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, err error) {
+			receivedErrs = append(receivedErrs, err)
+		},
+	}
+	_ = s.AddCommand(&Command{
+		Name:        "fail",
+		Description: "Always fails",
+		Handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) error {
+			return handlerErr
+		},
+	})
+
+	s.HandleInteractionCreate(nil, &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{Name: "fail"},
+		},
+	})
+	s.HandleInteractionCreate(nil, &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{Name: "missing"},
+		},
+	})
+	s.HandleInteractionCreate(nil, &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionPing,
+		},
+	})
+
+	if len(receivedErrs) != 3 {
+		t.Fatalf("expected 3 errors, got %d", len(receivedErrs))
+	}
+	if !errors.Is(receivedErrs[0], handlerErr) {
+		t.Errorf("got unexpected error: %s", receivedErrs[0])
+	}
+	if !errors.Is(receivedErrs[1], ErrUnknownCommand) {
+		t.Errorf("got unexpected error: %s", receivedErrs[1])
+	}
+	if !errors.Is(receivedErrs[2], ErrUnsupportedInteractionType) {
+		t.Errorf("got unexpected error: %s", receivedErrs[2])
+	}
+}
+
+func TestSwitchboard_HandleInteractionCreate_WithSameNameCommands(t *testing.T) {
+	var calls []string
+
+	s := &Switchboard{}
+	for _, command := range []*Command{
+		{
+			Name:        "inspect",
+			Description: "Inspect something",
+			Handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {
+				calls = append(calls, "slash")
+			},
+		},
+		{
+			Name: "inspect",
+			Type: MessageCommand,
+			Handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ *discordgo.Message) {
+				calls = append(calls, "message")
+			},
+		},
+		{
+			Name: "inspect",
+			Type: UserCommand,
+			Handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ *discordgo.User) {
+				calls = append(calls, "user")
+			},
+		},
+	} {
+		err := s.AddCommand(command)
+		if err != nil {
+			t.Fatalf("got unexpected error adding command: %s", err)
+		}
+	}
+
+	for _, data := range []discordgo.ApplicationCommandInteractionData{
+		{
+			Name:     "inspect",
+			TargetID: "1",
+			Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+				Users: map[string]*discordgo.User{"1": {ID: "1"}},
+			},
+		},
+		{
+			Name:     "inspect",
+			TargetID: "2",
+			Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+				Messages: map[string]*discordgo.Message{"2": {ID: "2"}},
+				Users:    map[string]*discordgo.User{"3": {ID: "3"}},
+			},
+		},
+		{
+			Name: "inspect",
+		},
+	} {
+		s.HandleInteractionCreate(nil, &discordgo.InteractionCreate{
+			Interaction: &discordgo.Interaction{
+				Type: discordgo.InteractionApplicationCommand,
+				Data: data,
+			},
+		})
+	}
+
+	if diff := deep.Equal(calls, []string{"user", "message", "slash"}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestSwitchboard_HandleInteractionCreate_WithMismatchedCommandType(t *testing.T) {
+	var receivedErr error
+
+	s := &Switchboard{
+		ErrorHandler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, err error) {
+			receivedErr = err
+		},
+	}
+	_ = s.AddCommand(&Command{
+		Name:        "inspect",
+		Description: "Inspect something",
+		Handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {
+			t.Error("handler function unexpectedly called")
+		},
+	})
+
+	s.HandleInteractionCreate(nil, &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name:     "inspect",
+				TargetID: "1",
+				Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+					Users: map[string]*discordgo.User{"1": {ID: "1"}},
+				},
+			},
+		},
+	})
+
+	if !errors.Is(receivedErr, ErrUnknownCommand) {
+		t.Errorf("got unexpected error: %v", receivedErr)
+	}
+}
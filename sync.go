@@ -0,0 +1,356 @@
+package switchboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ScopeChanges describes the differences between the commands registered with Discord in a single scope and the
+// commands registered with a Switchboard for that scope.
+type ScopeChanges struct {
+	// The ID of the guild the scope is for, or an empty string for global commands.
+	GuildID string
+
+	// Commands which are registered with the Switchboard but not with Discord.
+	Added []*discordgo.ApplicationCommand
+	// Commands which are registered with both, but differ.
+	Updated []*CommandUpdate
+	// Commands which are registered with Discord but no longer registered with the Switchboard.
+	Removed []*discordgo.ApplicationCommand
+}
+
+// HasChanges reports whether the commands registered with Discord differ from those registered with the Switchboard.
+func (c *ScopeChanges) HasChanges() bool {
+	return len(c.Added) > 0 || len(c.Updated) > 0 || len(c.Removed) > 0
+}
+
+// SyncReport describes the outcome of syncing commands with Discord.
+type SyncReport struct {
+	// Every scope which was checked, in order of guild ID, with global commands first.
+	Scopes []*ScopeChanges
+}
+
+// HasChanges reports whether the commands registered with Discord differ from the Switchboard's in any scope.
+func (r *SyncReport) HasChanges() bool {
+	return len(r.Changed()) > 0
+}
+
+// Changed returns the scopes which differ from Discord, and are overwritten when syncing.
+func (r *SyncReport) Changed() []*ScopeChanges {
+	var changed []*ScopeChanges
+
+	for _, scope := range r.Scopes {
+		if scope.HasChanges() {
+			changed = append(changed, scope)
+		}
+	}
+
+	return changed
+}
+
+type commandKey struct {
+	Name string
+	Type discordgo.ApplicationCommandType
+}
+
+func getCommandKey(command *discordgo.ApplicationCommand) commandKey {
+	commandType := command.Type
+	if commandType == 0 {
+		commandType = discordgo.ChatApplicationCommand
+	}
+
+	return commandKey{Name: command.Name, Type: commandType}
+}
+
+// pruneEmptyValues removes keys from decoded JSON whose values are equivalent to them being omitted, so that fields
+// Discord fills in with their defaults do not register as differences.
+func pruneEmptyValues(value any) any {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		for key, item := range typedValue {
+			item = pruneEmptyValues(item)
+
+			switch typedItem := item.(type) {
+			case nil:
+				delete(typedValue, key)
+				continue
+			case bool:
+				if !typedItem {
+					delete(typedValue, key)
+					continue
+				}
+			case string:
+				if typedItem == "" {
+					delete(typedValue, key)
+					continue
+				}
+			case []any:
+				if len(typedItem) == 0 {
+					delete(typedValue, key)
+					continue
+				}
+			case map[string]any:
+				if len(typedItem) == 0 {
+					delete(typedValue, key)
+					continue
+				}
+			}
+
+			typedValue[key] = item
+		}
+	case []any:
+		for index, item := range typedValue {
+			typedValue[index] = pruneEmptyValues(item)
+		}
+	}
+
+	return value
+}
+
+// normalizeCommand converts a command into a form which can be compared semantically, ignoring fields assigned by
+// Discord and the difference between omitted fields and those set to their defaults.
+func normalizeCommand(command *discordgo.ApplicationCommand, guildID string) (map[string]any, error) {
+	normalized := *command
+	normalized.ID = ""
+	normalized.ApplicationID = ""
+	normalized.GuildID = ""
+	normalized.Version = ""
+	normalized.DefaultPermission = nil
+	normalized.Type = getCommandKey(command).Type
+
+	// DM permission only applies to global commands, where it defaults to true
+	if guildID != "" {
+		normalized.DMPermission = nil
+	} else if normalized.DMPermission == nil {
+		dmPermission := true
+		normalized.DMPermission = &dmPermission
+	}
+
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding command %s: %w", command.Name, err)
+	}
+
+	var decoded map[string]any
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding command %s: %w", command.Name, err)
+	}
+
+	dmPermission, hasDMPermission := decoded["dm_permission"]
+	pruneEmptyValues(decoded)
+	// An explicit false DM permission is meaningful, so is restored after pruning
+	if hasDMPermission {
+		decoded["dm_permission"] = dmPermission
+	}
+
+	return decoded, nil
+}
+
+// compareCommands determines how the commands registered with Discord in a scope differ from the local commands.
+func compareCommands(guildID string, local, remote []*discordgo.ApplicationCommand) (*ScopeChanges, error) {
+	changes := &ScopeChanges{GuildID: guildID}
+
+	remoteCommands := map[commandKey]*discordgo.ApplicationCommand{}
+	for _, command := range remote {
+		remoteCommands[getCommandKey(command)] = command
+	}
+
+	localKeys := map[commandKey]bool{}
+	for _, command := range local {
+		key := getCommandKey(command)
+		localKeys[key] = true
+
+		remoteCommand, exists := remoteCommands[key]
+		if !exists {
+			changes.Added = append(changes.Added, command)
+			continue
+		}
+
+		normalizedLocal, err := normalizeCommand(command, guildID)
+		if err != nil {
+			return nil, err
+		}
+
+		normalizedRemote, err := normalizeCommand(remoteCommand, guildID)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldChanges := diffValues("", normalizedRemote, normalizedLocal)
+		if len(fieldChanges) > 0 {
+			changes.Updated = append(changes.Updated, &CommandUpdate{Command: command, Changes: fieldChanges})
+		}
+	}
+
+	for _, command := range remote {
+		if !localKeys[getCommandKey(command)] {
+			changes.Removed = append(changes.Removed, command)
+		}
+	}
+
+	return changes, nil
+}
+
+// getDiscordCommands converts every command registered with the Switchboard into its Discord representation, grouped
+// by guild ID. Only scopes with commands are included.
+func (s *Switchboard) getDiscordCommands() (map[string][]*discordgo.ApplicationCommand, error) {
+	guildCommands := map[string][]*discordgo.ApplicationCommand{}
+
+	for _, command := range s.commands {
+		discordCommand, err := command.ToDiscordCommand()
+		if err != nil {
+			return nil, fmt.Errorf("error generating discord command for command %s: %w", command.Name, err)
+		}
+		guildCommands[command.GuildID] = append(guildCommands[command.GuildID], discordCommand)
+	}
+
+	return guildCommands, nil
+}
+
+// PruneOptions configures which scopes are checked for stale commands when syncing. Any of these scopes which no longer
+// have commands registered with the Switchboard have their commands removed.
+type PruneOptions struct {
+	// Whether global commands should be checked for stale commands.
+	Global bool
+
+	// Guilds which should be checked for stale commands.
+	GuildIDs []string
+
+	// Whether every guild in the session's state should also be checked.
+	SessionGuilds bool
+}
+
+// getGuildIDs returns the IDs of every guild which should be checked for stale commands.
+func (o *PruneOptions) getGuildIDs(session *discordgo.Session) []string {
+	guildIDs := append([]string{}, o.GuildIDs...)
+
+	if o.SessionGuilds && session.State != nil {
+		session.State.RLock()
+		for _, guild := range session.State.Guilds {
+			guildIDs = append(guildIDs, guild.ID)
+		}
+		session.State.RUnlock()
+	}
+
+	return guildIDs
+}
+
+// addPrunedScopes adds an empty scope for every scope which should be pruned and has no commands to be synced, such
+// that any commands registered with Discord for those scopes are removed.
+func addPrunedScopes(
+	session *discordgo.Session,
+	prune *PruneOptions,
+	guildCommands map[string][]*discordgo.ApplicationCommand,
+) {
+	if prune == nil {
+		return
+	}
+
+	guildIDs := prune.getGuildIDs(session)
+	if prune.Global {
+		guildIDs = append(guildIDs, "")
+	}
+
+	for _, guildID := range guildIDs {
+		if _, exists := guildCommands[guildID]; !exists {
+			guildCommands[guildID] = []*discordgo.ApplicationCommand{}
+		}
+	}
+}
+
+// getSortedGuildIDs returns the guild IDs of a set of scopes in a deterministic order, with global commands first.
+func getSortedGuildIDs(guildCommands map[string][]*discordgo.ApplicationCommand) []string {
+	guildIDs := make([]string, 0, len(guildCommands))
+	for guildID := range guildCommands {
+		guildIDs = append(guildIDs, guildID)
+	}
+	sort.Strings(guildIDs)
+
+	return guildIDs
+}
+
+// planCommands fetches the commands currently registered with Discord for each scope and compares them against the
+// commands to be synced.
+func planCommands(
+	session *discordgo.Session,
+	appId string,
+	guildCommands map[string][]*discordgo.ApplicationCommand,
+) (*SyncReport, error) {
+	report := &SyncReport{}
+
+	for _, guildId := range getSortedGuildIDs(guildCommands) {
+		remoteCommands, err := session.ApplicationCommands(appId, guildId)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching commands for guild %s: %w", guildId, err)
+		}
+
+		changes, err := compareCommands(guildId, guildCommands[guildId], remoteCommands)
+		if err != nil {
+			return nil, fmt.Errorf("error comparing commands for guild %s: %w", guildId, err)
+		}
+
+		report.Scopes = append(report.Scopes, changes)
+	}
+
+	return report, nil
+}
+
+// applyPlan overwrites the commands of every scope which a report found to differ from Discord.
+func applyPlan(
+	session *discordgo.Session,
+	appId string,
+	report *SyncReport,
+	guildCommands map[string][]*discordgo.ApplicationCommand,
+) error {
+	for _, changes := range report.Changed() {
+		_, err := session.ApplicationCommandBulkOverwrite(appId, changes.GuildID, guildCommands[changes.GuildID])
+		if err != nil {
+			return fmt.Errorf("error syncing commands for guild %s: %w", changes.GuildID, err)
+		}
+	}
+
+	return nil
+}
+
+// planSync compares the Switchboard's commands against those registered with Discord, returning the changes along
+// with the commands for each scope.
+func (s *Switchboard) planSync(
+	session *discordgo.Session,
+	appId string,
+) (*SyncReport, map[string][]*discordgo.ApplicationCommand, error) {
+	guildCommands, err := s.getDiscordCommands()
+	if err != nil {
+		return nil, nil, err
+	}
+	addPrunedScopes(session, s.Prune, guildCommands)
+
+	report, err := planCommands(session, appId, guildCommands)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return report, guildCommands, nil
+}
+
+// PlanSync determines the changes SyncCommands would make, without writing anything to Discord.
+func (s *Switchboard) PlanSync(session *discordgo.Session, appId string) (*SyncReport, error) {
+	report, _, err := s.planSync(session, appId)
+	return report, err
+}
+
+// SyncCommands registers the Switchboard's commands with Discord. The commands currently registered for each scope
+// are fetched and compared against the Switchboard's commands, and only scopes which differ are overwritten. If Prune
+// is set, the scopes it specifies are also checked, and any commands in them which are no longer registered with the
+// Switchboard are removed.
+func (s *Switchboard) SyncCommands(session *discordgo.Session, appId string) (*SyncReport, error) {
+	report, guildCommands, err := s.planSync(session, appId)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, applyPlan(session, appId, report, guildCommands)
+}
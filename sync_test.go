@@ -0,0 +1,286 @@
+package switchboard
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+const remoteGlobalCommands = `[
+	{
+		"id": "100",
+		"application_id": "app",
+		"version": "200",
+		"type": 1,
+		"name": "roll",
+		"description": "Roll a die",
+		"default_permission": true,
+		"default_member_permissions": null,
+		"dm_permission": true,
+		"nsfw": false,
+		"options": [
+			{
+				"type": 4,
+				"name": "sides",
+				"description": "Number of sides",
+				"required": true,
+				"choices": [{"name": "6", "value": 6}, {"name": "20", "value": 20}]
+			}
+		]
+	}
+]`
+
+const remoteGuildCommands = `[
+	{
+		"id": "101",
+		"application_id": "app",
+		"guild_id": "1",
+		"version": "201",
+		"type": 1,
+		"name": "echo",
+		"description": "An outdated description",
+		"options": [{"type": 3, "name": "text", "description": "Text to echo", "required": true}]
+	},
+	{
+		"id": "102",
+		"application_id": "app",
+		"guild_id": "1",
+		"version": "202",
+		"type": 1,
+		"name": "removed",
+		"description": "A command which has since been removed"
+	}
+]`
+
+type rollArgs struct {
+	Sides int `description:"Number of sides" choices:"6,20"`
+}
+
+type echoArgs struct {
+	Text string `description:"Text to echo"`
+}
+
+func newSyncTestSwitchboard(t *testing.T) *Switchboard {
+	t.Helper()
+
+	s := &Switchboard{}
+
+	for _, command := range []*Command{
+		{
+			Name:        "roll",
+			Description: "Roll a die",
+			Handler:     func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ rollArgs) {},
+		},
+		{
+			Name:        "echo",
+			Description: "Echo some text",
+			Handler:     func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ echoArgs) {},
+			GuildID:     "1",
+		},
+	} {
+		err := s.AddCommand(command)
+		if err != nil {
+			t.Fatalf("got unexpected error adding command: %s", err)
+		}
+	}
+
+	return s
+}
+
+func respondWithRemoteCommands(request *http.Request) (int, string) {
+	if request.Method != http.MethodGet {
+		return http.StatusOK, "[]"
+	}
+
+	switch request.URL.Path {
+	case "/api/v9/applications/app/commands":
+		return http.StatusOK, remoteGlobalCommands
+	case "/api/v9/applications/app/guilds/1/commands":
+		return http.StatusOK, remoteGuildCommands
+	default:
+		return http.StatusOK, "[]"
+	}
+}
+
+func TestSwitchboard_SyncCommands(t *testing.T) {
+	s := newSyncTestSwitchboard(t)
+	session, getRequests := newTestSession(t, respondWithRemoteCommands)
+
+	report, err := s.SyncCommands(session, "app")
+	if err != nil {
+		t.Fatalf("got unexpected error syncing commands: %s", err)
+	}
+
+	var methods []string
+	for _, request := range getRequests() {
+		methods = append(methods, request.Method+" "+request.Path)
+	}
+
+	if diff := deep.Equal(methods, []string{
+		"GET /api/v9/applications/app/commands",
+		"GET /api/v9/applications/app/guilds/1/commands",
+		"PUT /api/v9/applications/app/guilds/1/commands",
+	}); diff != nil {
+		t.Error(diff)
+	}
+
+	if len(report.Scopes) != 2 {
+		t.Fatalf("expected 2 scopes, got %d", len(report.Scopes))
+	}
+
+	if report.Scopes[0].GuildID != "" || report.Scopes[0].HasChanges() {
+		t.Errorf("expected unchanged global scope, got %#v", report.Scopes[0])
+	}
+
+	changed := report.Changed()
+	if len(changed) != 1 {
+		t.Fatalf("expected 1 changed scope, got %d", len(changed))
+	}
+
+	if changed[0].GuildID != "1" ||
+		len(changed[0].Added) != 0 ||
+		len(changed[0].Updated) != 1 || changed[0].Updated[0].Command.Name != "echo" ||
+		len(changed[0].Removed) != 1 || changed[0].Removed[0].Name != "removed" {
+		t.Errorf("got unexpected changes: %#v", changed[0])
+	}
+}
+
+func Test_compareCommands(t *testing.T) {
+	dmPermission := false
+
+	changes, err := compareCommands(
+		"",
+		[]*discordgo.ApplicationCommand{
+			{Name: "ping", Description: "Ping", Type: discordgo.ChatApplicationCommand, DMPermission: &dmPermission},
+			{Name: "info", Type: discordgo.UserApplicationCommand},
+		},
+		[]*discordgo.ApplicationCommand{
+			{ID: "1", Name: "ping", Description: "Ping", Type: discordgo.ChatApplicationCommand},
+			{ID: "2", Name: "info", Type: discordgo.MessageApplicationCommand},
+		},
+	)
+	if err != nil {
+		t.Fatalf("got unexpected error comparing commands: %s", err)
+	}
+
+	if len(changes.Updated) != 1 || changes.Updated[0].Command.Name != "ping" {
+		t.Errorf("expected DM permission change to be detected, got %#v", changes.Updated)
+	}
+
+	if len(changes.Added) != 1 || changes.Added[0].Type != discordgo.UserApplicationCommand ||
+		len(changes.Removed) != 1 || changes.Removed[0].Type != discordgo.MessageApplicationCommand {
+		t.Errorf("expected commands of different types to be treated separately, got %#v", changes)
+	}
+}
+
+func TestSwitchboard_SyncCommands_WithPrune(t *testing.T) {
+	s := newSyncTestSwitchboard(t)
+	s.Prune = &PruneOptions{GuildIDs: []string{"2", "1"}, SessionGuilds: true}
+
+	session, getRequests := newTestSession(t, func(request *http.Request) (int, string) {
+		if request.Method == http.MethodGet && request.URL.Path == "/api/v9/applications/app/guilds/2/commands" {
+			return http.StatusOK, `[{"id": "103", "type": 1, "name": "stale", "description": "A stale command"}]`
+		}
+
+		return respondWithRemoteCommands(request)
+	})
+
+	err := session.State.GuildAdd(&discordgo.Guild{ID: "3"})
+	if err != nil {
+		t.Fatalf("got unexpected error adding guild to state: %s", err)
+	}
+
+	report, err := s.SyncCommands(session, "app")
+	if err != nil {
+		t.Fatalf("got unexpected error syncing commands: %s", err)
+	}
+
+	var writes []string
+	for _, request := range getRequests() {
+		if request.Method != http.MethodGet {
+			writes = append(writes, request.Method+" "+request.Path)
+
+			if request.Path == "/api/v9/applications/app/guilds/2/commands" && request.RawBody != "[]" {
+				t.Errorf("expected pruned guild to be cleared, got %s", request.RawBody)
+			}
+		}
+	}
+
+	if diff := deep.Equal(writes, []string{
+		"PUT /api/v9/applications/app/guilds/1/commands",
+		"PUT /api/v9/applications/app/guilds/2/commands",
+	}); diff != nil {
+		t.Error(diff)
+	}
+
+	var guildIDs []string
+	for _, scope := range report.Scopes {
+		guildIDs = append(guildIDs, scope.GuildID)
+	}
+
+	if diff := deep.Equal(guildIDs, []string{"", "1", "2", "3"}); diff != nil {
+		t.Error(diff)
+	}
+
+	if len(report.Scopes[2].Removed) != 1 || report.Scopes[2].Removed[0].Name != "stale" {
+		t.Errorf("expected stale command to be removed, got %#v", report.Scopes[2])
+	}
+}
+
+func TestSwitchboard_SyncCommands_WithOnlyGuildCommands(t *testing.T) {
+	s := &Switchboard{}
+	err := s.AddCommand(&Command{
+		Name:        "echo",
+		Description: "An outdated description",
+		Handler:     func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ echoArgs) {},
+		GuildID:     "1",
+	})
+	if err != nil {
+		t.Fatalf("got unexpected error adding command: %s", err)
+	}
+
+	session, getRequests := newTestSession(t, respondWithRemoteCommands)
+
+	report, err := s.SyncCommands(session, "app")
+	if err != nil {
+		t.Fatalf("got unexpected error syncing commands: %s", err)
+	}
+
+	for _, request := range getRequests() {
+		if request.Path == "/api/v9/applications/app/commands" {
+			t.Errorf("expected global commands to be left alone, got %s %s", request.Method, request.Path)
+		}
+	}
+
+	if len(report.Scopes) != 1 || report.Scopes[0].GuildID != "1" {
+		t.Errorf("got unexpected scopes: %#v", report.Scopes)
+	}
+}
+
+func TestSwitchboard_SyncCommands_WithGlobalPrune(t *testing.T) {
+	s := &Switchboard{Prune: &PruneOptions{Global: true}}
+
+	session, getRequests := newTestSession(t, respondWithRemoteCommands)
+
+	report, err := s.SyncCommands(session, "app")
+	if err != nil {
+		t.Fatalf("got unexpected error syncing commands: %s", err)
+	}
+
+	var writes []string
+	for _, request := range getRequests() {
+		if request.Method != http.MethodGet {
+			writes = append(writes, request.Method+" "+request.Path+" "+request.RawBody)
+		}
+	}
+
+	if diff := deep.Equal(writes, []string{"PUT /api/v9/applications/app/commands []"}); diff != nil {
+		t.Error(diff)
+	}
+
+	if len(report.Scopes) != 1 || len(report.Scopes[0].Removed) != 1 || report.Scopes[0].Removed[0].Name != "roll" {
+		t.Errorf("expected stale global command to be removed, got %#v", report.Scopes)
+	}
+}
@@ -19,17 +19,14 @@ type testArgs struct {
 	Default  string            `description:"An optional argument, with a default" default:"testing"`
 }
 
-func testCommand(session *discordgo.Session, interaction *discordgo.InteractionCreate, args testArgs) {
+func testCommand(ctx *switchboard.Context, _ *discordgo.Session, _ *discordgo.InteractionCreate, args testArgs) {
 	fmt.Printf("%#+v\n", args)
-	err := session.InteractionRespond(interaction.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{
-				{
-					Title:       "Hello world!",
-					Description: "Hello world from _Switchboard_!",
-					Color:       0xFF55AA,
-				},
+	err := ctx.Reply(&discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       "Hello world!",
+				Description: "Hello world from _Switchboard_!",
+				Color:       0xFF55AA,
 			},
 		},
 	})
@@ -45,18 +42,28 @@ func main() {
 	}
 	session.Identify.Intents = discordgo.IntentsAllWithoutPrivileged
 
-	switchboardInstance := &switchboard.Switchboard{}
-	_ = switchboardInstance.AddCommand(&switchboard.Command{
+	switchboardInstance := &switchboard.Switchboard{
+		ErrorHandler: func(_ *discordgo.Session, interaction *discordgo.InteractionCreate, err error) {
+			log.Printf("Error handling interaction %s: %s", interaction.ID, err)
+		},
+		PanicMessage: "Something went wrong while handling your interaction.",
+	}
+	err = switchboardInstance.AddCommand(&switchboard.Command{
 		Name:        "test",
 		Description: "Hello world from Switchboard!",
 		Handler:     testCommand,
+		AutoDefer:   &switchboard.AutoDefer{},
 		GuildID:     os.Getenv("DISCORD_GUILD_ID"),
 	})
+	if err != nil {
+		log.Fatalf("error adding command: %s", err)
+	}
 	session.AddHandler(switchboardInstance.HandleInteractionCreate)
-	err = switchboardInstance.SyncCommands(session, os.Getenv("DISCORD_APP_ID"))
+	report, err := switchboardInstance.SyncCommands(session, os.Getenv("DISCORD_APP_ID"))
 	if err != nil {
 		log.Fatalf("error registering commands: %s", err)
 	}
+	log.Printf("Synced commands:\n%s", report)
 
 	err = session.Open()
 	if err != nil {
@@ -67,6 +74,8 @@ func main() {
 	signal.Notify(sc, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
 	<-sc
 
+	switchboardInstance.Close()
+
 	if err = session.Close(); err != nil {
 		log.Fatalf("error closing session: %s", err)
 	}
@@ -0,0 +1,335 @@
+package switchboard
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	maxCommandNameLength = 32
+	maxDescriptionLength = 100
+	maxOptions           = 25
+)
+
+// ValidationError lists every problem found when validating a command.
+type ValidationError struct {
+	Command  string
+	Problems []error
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		messages = append(messages, "\n  - "+problem.Error())
+	}
+
+	return fmt.Sprintf("invalid command %s:%s", e.Command, strings.Join(messages, ""))
+}
+
+// Is reports whether any of the problems with the command match target.
+func (e *ValidationError) Is(target error) bool {
+	for _, problem := range e.Problems {
+		if errors.Is(problem, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first problem with the command which matches target.
+func (e *ValidationError) As(target any) bool {
+	for _, problem := range e.Problems {
+		if errors.As(problem, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateCommandName checks that a name is valid for the given type of command. Slash commands, as well as their
+// subcommands and subcommand groups, follow the same rules as option names, while other commands may use any
+// characters.
+func validateCommandName(commandType CommandType, name string) error {
+	if commandType != SlashCommand {
+		length := utf8.RuneCountInString(name)
+		if length < 1 || length > maxCommandNameLength {
+			return fmt.Errorf("%w: %q must be 1-32 characters", ErrInvalidCommandName, name)
+		}
+
+		return nil
+	}
+
+	if !optionNameRegexp.MatchString(name) || strings.ToLower(name) != name {
+		return fmt.Errorf(
+			"%w: %q must be 1-32 lower case letters, numbers, dashes or underscores",
+			ErrInvalidCommandName,
+			name,
+		)
+	}
+
+	return nil
+}
+
+func validateDescription(description string) error {
+	length := utf8.RuneCountInString(description)
+	if length < 1 || length > maxDescriptionLength {
+		return fmt.Errorf("%w: must be 1-100 characters, got %d", ErrInvalidDescription, length)
+	}
+
+	return nil
+}
+
+// validateOptionList checks the constraints which apply to a list of options as a whole - that there are no more than
+// 25 of them, and that every required option comes before any optional ones.
+func validateOptionList(options []*discordgo.ApplicationCommandOption) []error {
+	var problems []error
+
+	if len(options) > maxOptions {
+		problems = append(problems, fmt.Errorf("%w: got %d", ErrTooManyOptions, len(options)))
+	}
+
+	var firstOptional *discordgo.ApplicationCommandOption
+	for _, option := range options {
+		if !option.Required {
+			if firstOptional == nil {
+				firstOptional = option
+			}
+			continue
+		}
+
+		if firstOptional != nil {
+			problems = append(problems, fmt.Errorf(
+				"%w: required option %s comes after optional option %s",
+				ErrRequiredOptionAfterOptional,
+				option.Name,
+				firstOptional.Name,
+			))
+		}
+	}
+
+	return problems
+}
+
+// validateSlashHandler collects every problem with a slash command handler and the options generated from it.
+func validateSlashHandler(handler any, naming NamingStrategy) []error {
+	err := validateSlashCommand(handler)
+	if err != nil {
+		return []error{fmt.Errorf("invalid handler: %w", err)}
+	}
+
+	var problems []error
+
+	argsType := getArgsType(handler)
+
+	err = validateFieldNames(argsType, naming)
+	if err != nil {
+		problems = append(problems, err)
+	}
+
+	var options []*discordgo.ApplicationCommandOption
+	for index := 0; index < argsType.NumField(); index++ {
+		option, err := getCommandOption(argsType.Field(index), naming)
+		if err != nil {
+			problems = append(problems, err)
+			continue
+		}
+
+		options = append(options, option)
+	}
+
+	return append(problems, validateOptionList(options)...)
+}
+
+// prefixProblems adds context to each of a list of problems.
+func prefixProblems(prefix string, problems []error) []error {
+	prefixed := make([]error, 0, len(problems))
+	for _, problem := range problems {
+		prefixed = append(prefixed, fmt.Errorf("%s: %w", prefix, problem))
+	}
+
+	return prefixed
+}
+
+// validateSubCommand collects every problem with a subcommand.
+func validateSubCommand(subCommand *SubCommand, naming NamingStrategy) []error {
+	var problems []error
+
+	err := validateCommandName(SlashCommand, subCommand.Name)
+	if err != nil {
+		problems = append(problems, err)
+	}
+
+	err = validateDescription(subCommand.Description)
+	if err != nil {
+		problems = append(problems, err)
+	}
+
+	return append(problems, validateSlashHandler(subCommand.Handler, naming)...)
+}
+
+// findDuplicateNames reports every name used by more than one subcommand or subcommand group at the same level.
+func findDuplicateNames(names []string) []error {
+	var problems []error
+	counts := map[string]int{}
+
+	for _, name := range names {
+		counts[name]++
+		if counts[name] == 2 {
+			problems = append(problems, fmt.Errorf("%w: %s", ErrDuplicateSubCommandName, name))
+		}
+	}
+
+	return problems
+}
+
+// validateSubCommandTree collects every problem with a command's subcommands and subcommand groups.
+func (c *Command) validateSubCommandTree() []error {
+	var problems []error
+
+	if c.Type != SlashCommand {
+		problems = append(problems, ErrSubCommandsOnNonSlashCommand)
+	}
+
+	if c.Handler != nil {
+		problems = append(problems, ErrSubCommandsWithHandler)
+	}
+
+	if count := len(c.SubCommands) + len(c.SubCommandGroups); count > maxOptions {
+		problems = append(problems, fmt.Errorf("%w: got %d subcommands and groups", ErrTooManyOptions, count))
+	}
+
+	// Subcommands and groups at the top level of a command share a single set of names
+	topLevelNames := make([]string, 0, len(c.SubCommands)+len(c.SubCommandGroups))
+	for _, group := range c.SubCommandGroups {
+		topLevelNames = append(topLevelNames, group.Name)
+	}
+	for _, subCommand := range c.SubCommands {
+		topLevelNames = append(topLevelNames, subCommand.Name)
+	}
+	problems = append(problems, findDuplicateNames(topLevelNames)...)
+
+	for _, group := range c.SubCommandGroups {
+		prefix := "subcommand group " + group.Name
+
+		err := validateCommandName(SlashCommand, group.Name)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", prefix, err))
+		}
+
+		err = validateDescription(group.Description)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", prefix, err))
+		}
+
+		if len(group.SubCommands) == 0 {
+			problems = append(problems, fmt.Errorf("%s: %w", prefix, ErrEmptySubCommandGroup))
+		}
+
+		if len(group.SubCommands) > maxOptions {
+			problems = append(problems, fmt.Errorf("%s: %w: got %d", prefix, ErrTooManyOptions, len(group.SubCommands)))
+		}
+
+		groupNames := make([]string, 0, len(group.SubCommands))
+		for _, subCommand := range group.SubCommands {
+			groupNames = append(groupNames, subCommand.Name)
+		}
+		problems = append(problems, prefixProblems(prefix, findDuplicateNames(groupNames))...)
+
+		for _, subCommand := range group.SubCommands {
+			problems = append(problems, prefixProblems(
+				fmt.Sprintf("subcommand %s %s", group.Name, subCommand.Name),
+				validateSubCommand(subCommand, c.getNamingStrategy()),
+			)...)
+		}
+	}
+
+	for _, subCommand := range c.SubCommands {
+		problems = append(problems, prefixProblems(
+			"subcommand "+subCommand.Name,
+			validateSubCommand(subCommand, c.getNamingStrategy()),
+		)...)
+	}
+
+	return problems
+}
+
+// collectProblems finds every problem with a command, independently of any other commands.
+func (c *Command) collectProblems() []error {
+	var problems []error
+
+	err := validateCommandName(c.Type, c.Name)
+	if err != nil {
+		problems = append(problems, err)
+	}
+
+	if c.Type == SlashCommand {
+		err = validateDescription(c.Description)
+		if err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	if c.hasSubCommands() {
+		problems = append(problems, c.validateSubCommandTree()...)
+	} else if c.Type == SlashCommand {
+		problems = append(problems, validateSlashHandler(c.Handler, c.getNamingStrategy())...)
+	} else {
+		err = validateHandler(c.Type, c.Handler)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("invalid handler: %w", err))
+		}
+	}
+
+	// Member options can only be checked once every handler is known to be valid
+	if len(problems) == 0 && c.canRunInDMs() {
+		for _, handler := range c.getSlashHandlers() {
+			if hasMemberOption(handler) {
+				problems = append(problems, ErrMemberOptionInDMs)
+				break
+			}
+		}
+	}
+
+	return problems
+}
+
+// validate checks a command independently of any other commands, returning a ValidationError listing every problem
+// found.
+func (c *Command) validate() error {
+	problems := c.collectProblems()
+	if len(problems) > 0 {
+		return &ValidationError{Command: c.Name, Problems: problems}
+	}
+
+	return nil
+}
+
+// validateCommand checks a command before it is added to the Switchboard, returning a ValidationError listing every
+// problem found.
+func (s *Switchboard) validateCommand(command *Command) error {
+	problems := command.collectProblems()
+
+	for _, existing := range s.commands {
+		if existing.Name == command.Name && existing.Type == command.Type && existing.GuildID == command.GuildID {
+			scope := "globally"
+			if command.GuildID != "" {
+				scope = "in guild " + command.GuildID
+			}
+
+			problems = append(problems, fmt.Errorf("%w: %s is already registered %s", ErrDuplicateCommand, command.Name, scope))
+			break
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Command: command.Name, Problems: problems}
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+package switchboard
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestSwitchboard_AddCommand_WithMultipleProblems(t *testing.T) {
+	s := &Switchboard{}
+
+	err := s.AddCommand(&Command{
+		Name: "Bad Name",
+		Handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct {
+			Optional *string   `description:"An optional option"`
+			Required string    `description:"A required option"`
+			Count    int       `description:"A count" default:"many"`
+			Invalid  complex64 `description:"An unsupported option"`
+			Missing  string
+		}) {
+		},
+	})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("got unexpected error: %v", err)
+	}
+
+	if len(validationErr.Problems) != 6 {
+		t.Errorf("expected 6 problems, got %d: %s", len(validationErr.Problems), err)
+	}
+
+	for _, expected := range []error{
+		ErrInvalidCommandName,
+		ErrInvalidDescription,
+		ErrRequiredOptionAfterOptional,
+		ErrInvalidArgumentType,
+	} {
+		if !errors.Is(err, expected) {
+			t.Errorf("expected error to include %q: %s", expected, err)
+		}
+	}
+
+	if len(s.commands) != 0 {
+		t.Error("invalid command was added")
+	}
+}
+
+func TestSwitchboard_AddCommand_WithPointerDefault(t *testing.T) {
+	s := &Switchboard{}
+
+	err := s.AddCommand(&Command{
+		Name:        "test",
+		Description: "Test command",
+		Handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct {
+			Text *string `description:"Some text" default:"a"`
+		}) {
+		},
+	})
+	if err != nil {
+		t.Errorf("got unexpected error adding command: %s", err)
+	}
+
+	err = s.AddCommand(&Command{
+		Name:        "other",
+		Description: "Other command",
+		Handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct {
+			Count *int `description:"A count" default:"many"`
+		}) {
+		},
+	})
+	if err == nil || errors.Is(err, ErrUnsupportedDefaultArgType) {
+		t.Errorf("got unexpected error: %v", err)
+	}
+}
+
+func TestSwitchboard_AddCommand_WithInvalidHandler(t *testing.T) {
+	s := &Switchboard{}
+
+	err := s.AddCommand(&Command{
+		Name:        "test",
+		Description: "Test command",
+		Handler:     func(_ *discordgo.Session) {},
+	})
+	if !errors.Is(err, ErrHandlerInvalidParameterCount) {
+		t.Errorf("got unexpected error: %v", err)
+	}
+}
+
+func TestSwitchboard_AddCommand_WithInvalidSubCommands(t *testing.T) {
+	s := &Switchboard{}
+
+	err := s.AddCommand(&Command{
+		Name:        "test",
+		Description: "Test command",
+		SubCommandGroups: []*SubCommandGroup{
+			{Name: "empty", Description: "An empty group"},
+		},
+		SubCommands: []*SubCommand{
+			{
+				Name:    "sub",
+				Handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {},
+			},
+		},
+	})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("got unexpected error: %v", err)
+	}
+
+	if len(validationErr.Problems) != 2 ||
+		!errors.Is(validationErr.Problems[0], ErrEmptySubCommandGroup) ||
+		!errors.Is(validationErr.Problems[1], ErrInvalidDescription) {
+		t.Errorf("got unexpected problems: %s", err)
+	}
+}
+
+func TestSwitchboard_AddCommand_WithDuplicateSubCommandNames(t *testing.T) {
+	s := &Switchboard{}
+	handler := func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {}
+
+	err := s.AddCommand(&Command{
+		Name:        "test",
+		Description: "Test command",
+		SubCommandGroups: []*SubCommandGroup{
+			{
+				Name:        "group",
+				Description: "A group",
+				SubCommands: []*SubCommand{
+					{Name: "sub", Description: "A subcommand", Handler: handler},
+					{Name: "sub", Description: "Another subcommand", Handler: handler},
+				},
+			},
+			{
+				Name:        "group",
+				Description: "Another group",
+				SubCommands: []*SubCommand{{Name: "sub", Description: "A subcommand", Handler: handler}},
+			},
+		},
+		SubCommands: []*SubCommand{
+			{Name: "other", Description: "A subcommand", Handler: handler},
+			{Name: "other", Description: "Another subcommand", Handler: handler},
+		},
+	})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("got unexpected error: %v", err)
+	}
+
+	if len(validationErr.Problems) != 3 {
+		t.Errorf("expected 3 problems, got %d: %s", len(validationErr.Problems), err)
+	}
+
+	for _, problem := range validationErr.Problems {
+		if !errors.Is(problem, ErrDuplicateSubCommandName) {
+			t.Errorf("got unexpected problem: %s", problem)
+		}
+	}
+}
+
+func TestCommand_ToDiscordCommand_WithInvalidCommand(t *testing.T) {
+	command := &Command{
+		Name: "Bad Name",
+		Handler: func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {
+		},
+	}
+
+	_, err := command.ToDiscordCommand()
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("got unexpected error: %v", err)
+	}
+
+	if !errors.Is(err, ErrInvalidCommandName) || !errors.Is(err, ErrInvalidDescription) {
+		t.Errorf("got unexpected problems: %s", err)
+	}
+}
+
+func TestSwitchboard_AddCommand_WithDuplicateCommand(t *testing.T) {
+	s := &Switchboard{}
+	handler := func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ struct{}) {}
+	userHandler := func(_ *discordgo.Session, _ *discordgo.InteractionCreate, _ *discordgo.User) {}
+
+	for _, command := range []*Command{
+		{Name: "test", Description: "Test command", Handler: handler},
+		{Name: "test", Description: "Test command", Handler: handler, GuildID: "1"},
+		{Name: "test", Handler: userHandler, Type: UserCommand},
+		{Name: "Test User", Handler: userHandler, Type: UserCommand},
+	} {
+		err := s.AddCommand(command)
+		if err != nil {
+			t.Errorf("got unexpected error adding command: %s", err)
+		}
+	}
+
+	err := s.AddCommand(&Command{Name: "test", Description: "Another test command", Handler: handler, GuildID: "1"})
+	if !errors.Is(err, ErrDuplicateCommand) {
+		t.Errorf("got unexpected error: %v", err)
+	}
+}
+
+func Test_validateOptionList_WithTooManyOptions(t *testing.T) {
+	var options []*discordgo.ApplicationCommandOption
+	for index := 0; index < 26; index++ {
+		options = append(options, &discordgo.ApplicationCommandOption{Name: fmt.Sprintf("option%d", index)})
+	}
+
+	problems := validateOptionList(options)
+	if len(problems) != 1 || !errors.Is(problems[0], ErrTooManyOptions) {
+		t.Errorf("got unexpected problems: %v", problems)
+	}
+}